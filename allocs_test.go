@@ -0,0 +1,76 @@
+package main
+
+import "testing"
+
+/*----- Extension: Allocation Assertions -----*/
+
+// allocRuns is how many times AllocsPerRun calls each function
+const allocRuns = 100
+
+// allocCase pins the number of heap allocations a call makes
+type allocCase struct {
+	name string
+	want float64 // average allocations per call
+	fn   func()
+}
+
+// Package-level sinks for allocation tests and benchmarks. Results must be
+// stored in them, or the compiler may prove they are unused and remove the
+// allocation entirely.
+var (
+	testSinkInt   int
+	testSinkPtr   *int
+	testSinkSlice []int
+)
+
+// assertAllocs fails the test unless fn allocates exactly want times per call
+func assertAllocs(t *testing.T, want float64, fn func()) {
+	t.Helper()
+	if got := testing.AllocsPerRun(allocRuns, fn); got != want {
+		t.Errorf("allocations per call = %v, want %v", got, want)
+	}
+}
+
+// runAllocCases runs every case as a subtest
+func runAllocCases(t *testing.T, tests []allocCase) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertAllocs(t, tt.want, tt.fn)
+		})
+	}
+}
+
+func TestPointerAllocations(t *testing.T) {
+	runAllocCases(t, []allocCase{
+		{name: "CreateOnStack stays on the stack", want: 0, fn: func() { testSinkInt = CreateOnStack() }},
+		{name: "CreateOnHeap escapes once", want: 1, fn: func() { testSinkPtr = CreateOnHeap() }},
+		{name: "DoublePointer writes in place", want: 0, fn: func() { DoublePointer(&testSinkInt) }},
+		{name: "SwapValues", want: 0, fn: func() { testSinkInt, _ = SwapValues(1, 2) }},
+	})
+}
+
+func TestHigherOrderAllocations(t *testing.T) {
+	small := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	large := make([]int, 1000)
+	for i := range large {
+		large[i] = i
+	}
+	double := func(x int) int { return x * 2 }
+	even := func(x int) bool { return x%2 == 0 }
+	none := func(int) bool { return false }
+
+	runAllocCases(t, []allocCase{
+		{name: "Apply small", want: 1, fn: func() { testSinkSlice = Apply(small, double) }},
+		{name: "Apply large", want: 1, fn: func() { testSinkSlice = Apply(large, double) }},
+		{name: "Apply empty", want: 0, fn: func() { testSinkSlice = Apply(nil, double) }},
+		{name: "Filter small", want: 1, fn: func() { testSinkSlice = Filter(small, even) }},
+		{name: "Filter large", want: 1, fn: func() { testSinkSlice = Filter(large, even) }},
+		{name: "Filter keeping nothing", want: 1, fn: func() { testSinkSlice = Filter(large, none) }},
+		{name: "Filter empty", want: 0, fn: func() { testSinkSlice = Filter(nil, even) }},
+		{name: "Pipeline small", want: 1, fn: func() { testSinkSlice = Pipeline(small, double, double) }},
+		{name: "Pipeline large", want: 1, fn: func() { testSinkSlice = Pipeline(large, double, double, double) }},
+		{name: "Pipeline without operations", want: 1, fn: func() { testSinkSlice = Pipeline(large) }},
+		{name: "Reduce", want: 0, fn: func() { testSinkInt = Reduce(large, 0, func(a, b int) int { return a + b }) }},
+	})
+}
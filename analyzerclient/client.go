@@ -0,0 +1,132 @@
+// Package analyzerclient is a Go client for the analyzer gRPC service
+// started with `analyzer grpc`. It hides the protobuf messages behind plain
+// int-based methods that mirror the library functions.
+package analyzerclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ErvinLinUB/go-advanced-lab/analyzerpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Client calls a remote Analyzer service
+type Client struct {
+	rpc  analyzerpb.AnalyzerClient
+	conn *grpc.ClientConn // nil when built with New
+}
+
+// Dial connects to the service at target, e.g. "localhost:9090"
+// The service is meant for local use, so the connection is not encrypted.
+func Dial(target string, opts ...grpc.DialOption) (*Client, error) {
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{rpc: analyzerpb.NewAnalyzerClient(conn), conn: conn}, nil
+}
+
+// New wraps an existing connection, which the caller remains responsible for
+func New(conn grpc.ClientConnInterface) *Client {
+	return &Client{rpc: analyzerpb.NewAnalyzerClient(conn)}
+}
+
+// Close closes the connection opened by Dial
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
+
+// Factorial returns n!
+func (c *Client) Factorial(ctx context.Context, n int) (int, error) {
+	resp, err := c.rpc.Factorial(ctx, &analyzerpb.FactorialRequest{N: int64(n)})
+	if err != nil {
+		return 0, err
+	}
+	return int(resp.GetResult()), nil
+}
+
+// IsPrime reports whether n is prime
+func (c *Client) IsPrime(ctx context.Context, n int) (bool, error) {
+	resp, err := c.rpc.IsPrime(ctx, &analyzerpb.IsPrimeRequest{N: int64(n)})
+	if err != nil {
+		return false, err
+	}
+	return resp.GetPrime(), nil
+}
+
+// Power returns base^exponent
+func (c *Client) Power(ctx context.Context, base, exponent int) (int, error) {
+	resp, err := c.rpc.Power(ctx, &analyzerpb.PowerRequest{Base: int64(base), Exponent: int64(exponent)})
+	if err != nil {
+		return 0, err
+	}
+	return int(resp.GetResult()), nil
+}
+
+// Factorize returns the prime factors of n in ascending order
+func (c *Client) Factorize(ctx context.Context, n int) ([]int, error) {
+	resp, err := c.rpc.Factorize(ctx, &analyzerpb.FactorizeRequest{N: int64(n)})
+	if err != nil {
+		return nil, err
+	}
+	return toInts(resp.GetFactors()), nil
+}
+
+// PrimesUpTo calls fn for each prime <= upto as it arrives on the stream
+// Returning an error from fn cancels the stream and is passed back.
+func (c *Client) PrimesUpTo(ctx context.Context, upto int, fn func(prime int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.rpc.PrimesUpTo(ctx, &analyzerpb.PrimesUpToRequest{Upto: int64(upto)})
+	if err != nil {
+		return err
+	}
+	for {
+		prime, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(int(prime.GetValue())); err != nil {
+			return err
+		}
+	}
+}
+
+// Eval evaluates an expression and returns an int, bool or []int
+func (c *Client) Eval(ctx context.Context, expression string) (any, error) {
+	resp, err := c.rpc.Eval(ctx, &analyzerpb.EvalRequest{Expression: expression})
+	if err != nil {
+		return nil, err
+	}
+
+	switch result := resp.GetResult().(type) {
+	case *analyzerpb.EvalResponse_Number:
+		return int(result.Number), nil
+	case *analyzerpb.EvalResponse_Boolean:
+		return result.Boolean, nil
+	case *analyzerpb.EvalResponse_List:
+		return toInts(result.List.GetValues()), nil
+	}
+	return nil, fmt.Errorf("analyzerclient: unexpected eval result %T", resp.GetResult())
+}
+
+// toInts converts a protobuf list back to ints
+func toInts(nums []int64) []int {
+	out := make([]int, len(nums))
+	for i, n := range nums {
+		out[i] = int(n)
+	}
+	return out
+}
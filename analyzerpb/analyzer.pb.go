@@ -0,0 +1,780 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: analyzerpb/analyzer.proto
+
+package analyzerpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type FactorialRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	N             int64                  `protobuf:"varint,1,opt,name=n,proto3" json:"n,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FactorialRequest) Reset() {
+	*x = FactorialRequest{}
+	mi := &file_analyzerpb_analyzer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FactorialRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FactorialRequest) ProtoMessage() {}
+
+func (x *FactorialRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzerpb_analyzer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FactorialRequest.ProtoReflect.Descriptor instead.
+func (*FactorialRequest) Descriptor() ([]byte, []int) {
+	return file_analyzerpb_analyzer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FactorialRequest) GetN() int64 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+type FactorialResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        int64                  `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FactorialResponse) Reset() {
+	*x = FactorialResponse{}
+	mi := &file_analyzerpb_analyzer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FactorialResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FactorialResponse) ProtoMessage() {}
+
+func (x *FactorialResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzerpb_analyzer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FactorialResponse.ProtoReflect.Descriptor instead.
+func (*FactorialResponse) Descriptor() ([]byte, []int) {
+	return file_analyzerpb_analyzer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FactorialResponse) GetResult() int64 {
+	if x != nil {
+		return x.Result
+	}
+	return 0
+}
+
+type IsPrimeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	N             int64                  `protobuf:"varint,1,opt,name=n,proto3" json:"n,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IsPrimeRequest) Reset() {
+	*x = IsPrimeRequest{}
+	mi := &file_analyzerpb_analyzer_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IsPrimeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsPrimeRequest) ProtoMessage() {}
+
+func (x *IsPrimeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzerpb_analyzer_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsPrimeRequest.ProtoReflect.Descriptor instead.
+func (*IsPrimeRequest) Descriptor() ([]byte, []int) {
+	return file_analyzerpb_analyzer_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *IsPrimeRequest) GetN() int64 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+type IsPrimeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prime         bool                   `protobuf:"varint,1,opt,name=prime,proto3" json:"prime,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IsPrimeResponse) Reset() {
+	*x = IsPrimeResponse{}
+	mi := &file_analyzerpb_analyzer_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IsPrimeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsPrimeResponse) ProtoMessage() {}
+
+func (x *IsPrimeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzerpb_analyzer_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsPrimeResponse.ProtoReflect.Descriptor instead.
+func (*IsPrimeResponse) Descriptor() ([]byte, []int) {
+	return file_analyzerpb_analyzer_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *IsPrimeResponse) GetPrime() bool {
+	if x != nil {
+		return x.Prime
+	}
+	return false
+}
+
+type PowerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          int64                  `protobuf:"varint,1,opt,name=base,proto3" json:"base,omitempty"`
+	Exponent      int64                  `protobuf:"varint,2,opt,name=exponent,proto3" json:"exponent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PowerRequest) Reset() {
+	*x = PowerRequest{}
+	mi := &file_analyzerpb_analyzer_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PowerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PowerRequest) ProtoMessage() {}
+
+func (x *PowerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzerpb_analyzer_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PowerRequest.ProtoReflect.Descriptor instead.
+func (*PowerRequest) Descriptor() ([]byte, []int) {
+	return file_analyzerpb_analyzer_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PowerRequest) GetBase() int64 {
+	if x != nil {
+		return x.Base
+	}
+	return 0
+}
+
+func (x *PowerRequest) GetExponent() int64 {
+	if x != nil {
+		return x.Exponent
+	}
+	return 0
+}
+
+type PowerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        int64                  `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PowerResponse) Reset() {
+	*x = PowerResponse{}
+	mi := &file_analyzerpb_analyzer_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PowerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PowerResponse) ProtoMessage() {}
+
+func (x *PowerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzerpb_analyzer_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PowerResponse.ProtoReflect.Descriptor instead.
+func (*PowerResponse) Descriptor() ([]byte, []int) {
+	return file_analyzerpb_analyzer_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PowerResponse) GetResult() int64 {
+	if x != nil {
+		return x.Result
+	}
+	return 0
+}
+
+type FactorizeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	N             int64                  `protobuf:"varint,1,opt,name=n,proto3" json:"n,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FactorizeRequest) Reset() {
+	*x = FactorizeRequest{}
+	mi := &file_analyzerpb_analyzer_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FactorizeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FactorizeRequest) ProtoMessage() {}
+
+func (x *FactorizeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzerpb_analyzer_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FactorizeRequest.ProtoReflect.Descriptor instead.
+func (*FactorizeRequest) Descriptor() ([]byte, []int) {
+	return file_analyzerpb_analyzer_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *FactorizeRequest) GetN() int64 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+type FactorizeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Factors       []int64                `protobuf:"varint,1,rep,packed,name=factors,proto3" json:"factors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FactorizeResponse) Reset() {
+	*x = FactorizeResponse{}
+	mi := &file_analyzerpb_analyzer_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FactorizeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FactorizeResponse) ProtoMessage() {}
+
+func (x *FactorizeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzerpb_analyzer_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FactorizeResponse.ProtoReflect.Descriptor instead.
+func (*FactorizeResponse) Descriptor() ([]byte, []int) {
+	return file_analyzerpb_analyzer_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FactorizeResponse) GetFactors() []int64 {
+	if x != nil {
+		return x.Factors
+	}
+	return nil
+}
+
+type PrimesUpToRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Upto          int64                  `protobuf:"varint,1,opt,name=upto,proto3" json:"upto,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PrimesUpToRequest) Reset() {
+	*x = PrimesUpToRequest{}
+	mi := &file_analyzerpb_analyzer_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PrimesUpToRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrimesUpToRequest) ProtoMessage() {}
+
+func (x *PrimesUpToRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzerpb_analyzer_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrimesUpToRequest.ProtoReflect.Descriptor instead.
+func (*PrimesUpToRequest) Descriptor() ([]byte, []int) {
+	return file_analyzerpb_analyzer_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PrimesUpToRequest) GetUpto() int64 {
+	if x != nil {
+		return x.Upto
+	}
+	return 0
+}
+
+type Prime struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         int64                  `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Prime) Reset() {
+	*x = Prime{}
+	mi := &file_analyzerpb_analyzer_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Prime) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Prime) ProtoMessage() {}
+
+func (x *Prime) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzerpb_analyzer_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Prime.ProtoReflect.Descriptor instead.
+func (*Prime) Descriptor() ([]byte, []int) {
+	return file_analyzerpb_analyzer_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Prime) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type EvalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Expression    string                 `protobuf:"bytes,1,opt,name=expression,proto3" json:"expression,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvalRequest) Reset() {
+	*x = EvalRequest{}
+	mi := &file_analyzerpb_analyzer_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvalRequest) ProtoMessage() {}
+
+func (x *EvalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzerpb_analyzer_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvalRequest.ProtoReflect.Descriptor instead.
+func (*EvalRequest) Descriptor() ([]byte, []int) {
+	return file_analyzerpb_analyzer_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *EvalRequest) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+type EvalResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*EvalResponse_Number
+	//	*EvalResponse_Boolean
+	//	*EvalResponse_List
+	Result        isEvalResponse_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvalResponse) Reset() {
+	*x = EvalResponse{}
+	mi := &file_analyzerpb_analyzer_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvalResponse) ProtoMessage() {}
+
+func (x *EvalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzerpb_analyzer_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvalResponse.ProtoReflect.Descriptor instead.
+func (*EvalResponse) Descriptor() ([]byte, []int) {
+	return file_analyzerpb_analyzer_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *EvalResponse) GetResult() isEvalResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *EvalResponse) GetNumber() int64 {
+	if x != nil {
+		if x, ok := x.Result.(*EvalResponse_Number); ok {
+			return x.Number
+		}
+	}
+	return 0
+}
+
+func (x *EvalResponse) GetBoolean() bool {
+	if x != nil {
+		if x, ok := x.Result.(*EvalResponse_Boolean); ok {
+			return x.Boolean
+		}
+	}
+	return false
+}
+
+func (x *EvalResponse) GetList() *IntList {
+	if x != nil {
+		if x, ok := x.Result.(*EvalResponse_List); ok {
+			return x.List
+		}
+	}
+	return nil
+}
+
+type isEvalResponse_Result interface {
+	isEvalResponse_Result()
+}
+
+type EvalResponse_Number struct {
+	Number int64 `protobuf:"varint,1,opt,name=number,proto3,oneof"`
+}
+
+type EvalResponse_Boolean struct {
+	Boolean bool `protobuf:"varint,2,opt,name=boolean,proto3,oneof"`
+}
+
+type EvalResponse_List struct {
+	List *IntList `protobuf:"bytes,3,opt,name=list,proto3,oneof"`
+}
+
+func (*EvalResponse_Number) isEvalResponse_Result() {}
+
+func (*EvalResponse_Boolean) isEvalResponse_Result() {}
+
+func (*EvalResponse_List) isEvalResponse_Result() {}
+
+type IntList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []int64                `protobuf:"varint,1,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IntList) Reset() {
+	*x = IntList{}
+	mi := &file_analyzerpb_analyzer_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IntList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntList) ProtoMessage() {}
+
+func (x *IntList) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzerpb_analyzer_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntList.ProtoReflect.Descriptor instead.
+func (*IntList) Descriptor() ([]byte, []int) {
+	return file_analyzerpb_analyzer_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *IntList) GetValues() []int64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+var File_analyzerpb_analyzer_proto protoreflect.FileDescriptor
+
+const file_analyzerpb_analyzer_proto_rawDesc = "" +
+	"\n" +
+	"\x19analyzerpb/analyzer.proto\x12\vanalyzer.v1\" \n" +
+	"\x10FactorialRequest\x12\f\n" +
+	"\x01n\x18\x01 \x01(\x03R\x01n\"+\n" +
+	"\x11FactorialResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\x03R\x06result\"\x1e\n" +
+	"\x0eIsPrimeRequest\x12\f\n" +
+	"\x01n\x18\x01 \x01(\x03R\x01n\"'\n" +
+	"\x0fIsPrimeResponse\x12\x14\n" +
+	"\x05prime\x18\x01 \x01(\bR\x05prime\">\n" +
+	"\fPowerRequest\x12\x12\n" +
+	"\x04base\x18\x01 \x01(\x03R\x04base\x12\x1a\n" +
+	"\bexponent\x18\x02 \x01(\x03R\bexponent\"'\n" +
+	"\rPowerResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\x03R\x06result\" \n" +
+	"\x10FactorizeRequest\x12\f\n" +
+	"\x01n\x18\x01 \x01(\x03R\x01n\"-\n" +
+	"\x11FactorizeResponse\x12\x18\n" +
+	"\afactors\x18\x01 \x03(\x03R\afactors\"'\n" +
+	"\x11PrimesUpToRequest\x12\x12\n" +
+	"\x04upto\x18\x01 \x01(\x03R\x04upto\"\x1d\n" +
+	"\x05Prime\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x03R\x05value\"-\n" +
+	"\vEvalRequest\x12\x1e\n" +
+	"\n" +
+	"expression\x18\x01 \x01(\tR\n" +
+	"expression\"z\n" +
+	"\fEvalResponse\x12\x18\n" +
+	"\x06number\x18\x01 \x01(\x03H\x00R\x06number\x12\x1a\n" +
+	"\aboolean\x18\x02 \x01(\bH\x00R\aboolean\x12*\n" +
+	"\x04list\x18\x03 \x01(\v2\x14.analyzer.v1.IntListH\x00R\x04listB\b\n" +
+	"\x06result\"!\n" +
+	"\aIntList\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\x03R\x06values2\xa9\x03\n" +
+	"\bAnalyzer\x12J\n" +
+	"\tFactorial\x12\x1d.analyzer.v1.FactorialRequest\x1a\x1e.analyzer.v1.FactorialResponse\x12D\n" +
+	"\aIsPrime\x12\x1b.analyzer.v1.IsPrimeRequest\x1a\x1c.analyzer.v1.IsPrimeResponse\x12>\n" +
+	"\x05Power\x12\x19.analyzer.v1.PowerRequest\x1a\x1a.analyzer.v1.PowerResponse\x12J\n" +
+	"\tFactorize\x12\x1d.analyzer.v1.FactorizeRequest\x1a\x1e.analyzer.v1.FactorizeResponse\x12B\n" +
+	"\n" +
+	"PrimesUpTo\x12\x1e.analyzer.v1.PrimesUpToRequest\x1a\x12.analyzer.v1.Prime0\x01\x12;\n" +
+	"\x04Eval\x12\x18.analyzer.v1.EvalRequest\x1a\x19.analyzer.v1.EvalResponseB2Z0github.com/ErvinLinUB/go-advanced-lab/analyzerpbb\x06proto3"
+
+var (
+	file_analyzerpb_analyzer_proto_rawDescOnce sync.Once
+	file_analyzerpb_analyzer_proto_rawDescData []byte
+)
+
+func file_analyzerpb_analyzer_proto_rawDescGZIP() []byte {
+	file_analyzerpb_analyzer_proto_rawDescOnce.Do(func() {
+		file_analyzerpb_analyzer_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_analyzerpb_analyzer_proto_rawDesc), len(file_analyzerpb_analyzer_proto_rawDesc)))
+	})
+	return file_analyzerpb_analyzer_proto_rawDescData
+}
+
+var file_analyzerpb_analyzer_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_analyzerpb_analyzer_proto_goTypes = []any{
+	(*FactorialRequest)(nil),  // 0: analyzer.v1.FactorialRequest
+	(*FactorialResponse)(nil), // 1: analyzer.v1.FactorialResponse
+	(*IsPrimeRequest)(nil),    // 2: analyzer.v1.IsPrimeRequest
+	(*IsPrimeResponse)(nil),   // 3: analyzer.v1.IsPrimeResponse
+	(*PowerRequest)(nil),      // 4: analyzer.v1.PowerRequest
+	(*PowerResponse)(nil),     // 5: analyzer.v1.PowerResponse
+	(*FactorizeRequest)(nil),  // 6: analyzer.v1.FactorizeRequest
+	(*FactorizeResponse)(nil), // 7: analyzer.v1.FactorizeResponse
+	(*PrimesUpToRequest)(nil), // 8: analyzer.v1.PrimesUpToRequest
+	(*Prime)(nil),             // 9: analyzer.v1.Prime
+	(*EvalRequest)(nil),       // 10: analyzer.v1.EvalRequest
+	(*EvalResponse)(nil),      // 11: analyzer.v1.EvalResponse
+	(*IntList)(nil),           // 12: analyzer.v1.IntList
+}
+var file_analyzerpb_analyzer_proto_depIdxs = []int32{
+	12, // 0: analyzer.v1.EvalResponse.list:type_name -> analyzer.v1.IntList
+	0,  // 1: analyzer.v1.Analyzer.Factorial:input_type -> analyzer.v1.FactorialRequest
+	2,  // 2: analyzer.v1.Analyzer.IsPrime:input_type -> analyzer.v1.IsPrimeRequest
+	4,  // 3: analyzer.v1.Analyzer.Power:input_type -> analyzer.v1.PowerRequest
+	6,  // 4: analyzer.v1.Analyzer.Factorize:input_type -> analyzer.v1.FactorizeRequest
+	8,  // 5: analyzer.v1.Analyzer.PrimesUpTo:input_type -> analyzer.v1.PrimesUpToRequest
+	10, // 6: analyzer.v1.Analyzer.Eval:input_type -> analyzer.v1.EvalRequest
+	1,  // 7: analyzer.v1.Analyzer.Factorial:output_type -> analyzer.v1.FactorialResponse
+	3,  // 8: analyzer.v1.Analyzer.IsPrime:output_type -> analyzer.v1.IsPrimeResponse
+	5,  // 9: analyzer.v1.Analyzer.Power:output_type -> analyzer.v1.PowerResponse
+	7,  // 10: analyzer.v1.Analyzer.Factorize:output_type -> analyzer.v1.FactorizeResponse
+	9,  // 11: analyzer.v1.Analyzer.PrimesUpTo:output_type -> analyzer.v1.Prime
+	11, // 12: analyzer.v1.Analyzer.Eval:output_type -> analyzer.v1.EvalResponse
+	7,  // [7:13] is the sub-list for method output_type
+	1,  // [1:7] is the sub-list for method input_type
+	1,  // [1:1] is the sub-list for extension type_name
+	1,  // [1:1] is the sub-list for extension extendee
+	0,  // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_analyzerpb_analyzer_proto_init() }
+func file_analyzerpb_analyzer_proto_init() {
+	if File_analyzerpb_analyzer_proto != nil {
+		return
+	}
+	file_analyzerpb_analyzer_proto_msgTypes[11].OneofWrappers = []any{
+		(*EvalResponse_Number)(nil),
+		(*EvalResponse_Boolean)(nil),
+		(*EvalResponse_List)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_analyzerpb_analyzer_proto_rawDesc), len(file_analyzerpb_analyzer_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_analyzerpb_analyzer_proto_goTypes,
+		DependencyIndexes: file_analyzerpb_analyzer_proto_depIdxs,
+		MessageInfos:      file_analyzerpb_analyzer_proto_msgTypes,
+	}.Build()
+	File_analyzerpb_analyzer_proto = out.File
+	file_analyzerpb_analyzer_proto_goTypes = nil
+	file_analyzerpb_analyzer_proto_depIdxs = nil
+}
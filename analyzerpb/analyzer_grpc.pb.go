@@ -0,0 +1,331 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: analyzerpb/analyzer.proto
+
+package analyzerpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Analyzer_Factorial_FullMethodName  = "/analyzer.v1.Analyzer/Factorial"
+	Analyzer_IsPrime_FullMethodName    = "/analyzer.v1.Analyzer/IsPrime"
+	Analyzer_Power_FullMethodName      = "/analyzer.v1.Analyzer/Power"
+	Analyzer_Factorize_FullMethodName  = "/analyzer.v1.Analyzer/Factorize"
+	Analyzer_PrimesUpTo_FullMethodName = "/analyzer.v1.Analyzer/PrimesUpTo"
+	Analyzer_Eval_FullMethodName       = "/analyzer.v1.Analyzer/Eval"
+)
+
+// AnalyzerClient is the client API for Analyzer service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Analyzer exposes the lab's math and functional APIs.
+type AnalyzerClient interface {
+	// Factorial returns n!, or INVALID_ARGUMENT for negative n or overflow.
+	Factorial(ctx context.Context, in *FactorialRequest, opts ...grpc.CallOption) (*FactorialResponse, error)
+	// IsPrime reports whether n is prime; n must be >= 2.
+	IsPrime(ctx context.Context, in *IsPrimeRequest, opts ...grpc.CallOption) (*IsPrimeResponse, error)
+	// Power returns base^exponent; the exponent must be non-negative.
+	Power(ctx context.Context, in *PowerRequest, opts ...grpc.CallOption) (*PowerResponse, error)
+	// Factorize returns the prime factors of n in ascending order.
+	Factorize(ctx context.Context, in *FactorizeRequest, opts ...grpc.CallOption) (*FactorizeResponse, error)
+	// PrimesUpTo streams every prime <= upto in ascending order.
+	PrimesUpTo(ctx context.Context, in *PrimesUpToRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Prime], error)
+	// Eval evaluates an expression in the analyzer eval language.
+	Eval(ctx context.Context, in *EvalRequest, opts ...grpc.CallOption) (*EvalResponse, error)
+}
+
+type analyzerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAnalyzerClient(cc grpc.ClientConnInterface) AnalyzerClient {
+	return &analyzerClient{cc}
+}
+
+func (c *analyzerClient) Factorial(ctx context.Context, in *FactorialRequest, opts ...grpc.CallOption) (*FactorialResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FactorialResponse)
+	err := c.cc.Invoke(ctx, Analyzer_Factorial_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyzerClient) IsPrime(ctx context.Context, in *IsPrimeRequest, opts ...grpc.CallOption) (*IsPrimeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IsPrimeResponse)
+	err := c.cc.Invoke(ctx, Analyzer_IsPrime_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyzerClient) Power(ctx context.Context, in *PowerRequest, opts ...grpc.CallOption) (*PowerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PowerResponse)
+	err := c.cc.Invoke(ctx, Analyzer_Power_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyzerClient) Factorize(ctx context.Context, in *FactorizeRequest, opts ...grpc.CallOption) (*FactorizeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FactorizeResponse)
+	err := c.cc.Invoke(ctx, Analyzer_Factorize_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyzerClient) PrimesUpTo(ctx context.Context, in *PrimesUpToRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Prime], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Analyzer_ServiceDesc.Streams[0], Analyzer_PrimesUpTo_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[PrimesUpToRequest, Prime]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Analyzer_PrimesUpToClient = grpc.ServerStreamingClient[Prime]
+
+func (c *analyzerClient) Eval(ctx context.Context, in *EvalRequest, opts ...grpc.CallOption) (*EvalResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EvalResponse)
+	err := c.cc.Invoke(ctx, Analyzer_Eval_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AnalyzerServer is the server API for Analyzer service.
+// All implementations must embed UnimplementedAnalyzerServer
+// for forward compatibility.
+//
+// Analyzer exposes the lab's math and functional APIs.
+type AnalyzerServer interface {
+	// Factorial returns n!, or INVALID_ARGUMENT for negative n or overflow.
+	Factorial(context.Context, *FactorialRequest) (*FactorialResponse, error)
+	// IsPrime reports whether n is prime; n must be >= 2.
+	IsPrime(context.Context, *IsPrimeRequest) (*IsPrimeResponse, error)
+	// Power returns base^exponent; the exponent must be non-negative.
+	Power(context.Context, *PowerRequest) (*PowerResponse, error)
+	// Factorize returns the prime factors of n in ascending order.
+	Factorize(context.Context, *FactorizeRequest) (*FactorizeResponse, error)
+	// PrimesUpTo streams every prime <= upto in ascending order.
+	PrimesUpTo(*PrimesUpToRequest, grpc.ServerStreamingServer[Prime]) error
+	// Eval evaluates an expression in the analyzer eval language.
+	Eval(context.Context, *EvalRequest) (*EvalResponse, error)
+	mustEmbedUnimplementedAnalyzerServer()
+}
+
+// UnimplementedAnalyzerServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAnalyzerServer struct{}
+
+func (UnimplementedAnalyzerServer) Factorial(context.Context, *FactorialRequest) (*FactorialResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Factorial not implemented")
+}
+func (UnimplementedAnalyzerServer) IsPrime(context.Context, *IsPrimeRequest) (*IsPrimeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method IsPrime not implemented")
+}
+func (UnimplementedAnalyzerServer) Power(context.Context, *PowerRequest) (*PowerResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Power not implemented")
+}
+func (UnimplementedAnalyzerServer) Factorize(context.Context, *FactorizeRequest) (*FactorizeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Factorize not implemented")
+}
+func (UnimplementedAnalyzerServer) PrimesUpTo(*PrimesUpToRequest, grpc.ServerStreamingServer[Prime]) error {
+	return status.Error(codes.Unimplemented, "method PrimesUpTo not implemented")
+}
+func (UnimplementedAnalyzerServer) Eval(context.Context, *EvalRequest) (*EvalResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Eval not implemented")
+}
+func (UnimplementedAnalyzerServer) mustEmbedUnimplementedAnalyzerServer() {}
+func (UnimplementedAnalyzerServer) testEmbeddedByValue()                  {}
+
+// UnsafeAnalyzerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AnalyzerServer will
+// result in compilation errors.
+type UnsafeAnalyzerServer interface {
+	mustEmbedUnimplementedAnalyzerServer()
+}
+
+func RegisterAnalyzerServer(s grpc.ServiceRegistrar, srv AnalyzerServer) {
+	// If the following call panics, it indicates UnimplementedAnalyzerServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Analyzer_ServiceDesc, srv)
+}
+
+func _Analyzer_Factorial_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FactorialRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyzerServer).Factorial(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Analyzer_Factorial_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyzerServer).Factorial(ctx, req.(*FactorialRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Analyzer_IsPrime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsPrimeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyzerServer).IsPrime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Analyzer_IsPrime_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyzerServer).IsPrime(ctx, req.(*IsPrimeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Analyzer_Power_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PowerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyzerServer).Power(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Analyzer_Power_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyzerServer).Power(ctx, req.(*PowerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Analyzer_Factorize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FactorizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyzerServer).Factorize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Analyzer_Factorize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyzerServer).Factorize(ctx, req.(*FactorizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Analyzer_PrimesUpTo_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PrimesUpToRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AnalyzerServer).PrimesUpTo(m, &grpc.GenericServerStream[PrimesUpToRequest, Prime]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Analyzer_PrimesUpToServer = grpc.ServerStreamingServer[Prime]
+
+func _Analyzer_Eval_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyzerServer).Eval(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Analyzer_Eval_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyzerServer).Eval(ctx, req.(*EvalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Analyzer_ServiceDesc is the grpc.ServiceDesc for Analyzer service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Analyzer_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "analyzer.v1.Analyzer",
+	HandlerType: (*AnalyzerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Factorial",
+			Handler:    _Analyzer_Factorial_Handler,
+		},
+		{
+			MethodName: "IsPrime",
+			Handler:    _Analyzer_IsPrime_Handler,
+		},
+		{
+			MethodName: "Power",
+			Handler:    _Analyzer_Power_Handler,
+		},
+		{
+			MethodName: "Factorize",
+			Handler:    _Analyzer_Factorize_Handler,
+		},
+		{
+			MethodName: "Eval",
+			Handler:    _Analyzer_Eval_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "PrimesUpTo",
+			Handler:       _Analyzer_PrimesUpTo_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "analyzerpb/analyzer.proto",
+}
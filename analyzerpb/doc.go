@@ -0,0 +1,7 @@
+// Package analyzerpb holds the protobuf messages and gRPC stubs for the
+// Analyzer service defined in analyzer.proto.
+//
+// The .pb.go files are generated; edit analyzer.proto and run go generate.
+package analyzerpb
+
+//go:generate protoc -I .. --go_out=.. --go_opt=paths=source_relative --go-grpc_out=.. --go-grpc_opt=paths=source_relative ../analyzerpb/analyzer.proto
@@ -0,0 +1,63 @@
+package main
+
+import "sync/atomic"
+
+/*----- Extension: Atomic Pointer Updates -----*/
+
+// 1. CompareAndUpdate - replaces x with fn(x) atomically and returns the new
+// value
+// If another goroutine changes x between the load and the store, the update
+// is retried with the fresh value, so fn may run more than once and must
+// not have side effects.
+func CompareAndUpdate(x *atomic.Int64, fn func(int64) int64) int64 {
+	for {
+		old := x.Load()
+		updated := fn(old)
+		if x.CompareAndSwap(old, updated) {
+			return updated
+		}
+	}
+}
+
+// 2. AtomicDouble - DoublePointer for a value shared between goroutines
+func AtomicDouble(x *atomic.Int64) int64 {
+	return CompareAndUpdate(x, func(v int64) int64 { return v * 2 })
+}
+
+// AtomicPair holds two values that are always read and swapped together
+// The zero value holds two zero values.
+type AtomicPair[T any] struct {
+	v atomic.Pointer[[2]T]
+}
+
+// NewAtomicPair returns a pair holding a and b
+func NewAtomicPair[T any](a, b T) *AtomicPair[T] {
+	p := &AtomicPair[T]{}
+	p.v.Store(&[2]T{a, b})
+	return p
+}
+
+// Load returns both values from the same instant
+func (p *AtomicPair[T]) Load() (T, T) {
+	if v := p.v.Load(); v != nil {
+		return v[0], v[1]
+	}
+	var zero T
+	return zero, zero
+}
+
+// 3. AtomicSwap - SwapPointers for values shared between goroutines
+// Two separate atomics cannot be exchanged in one step, so the pair is
+// stored as a single immutable array and replaced with a swapped copy.
+func AtomicSwap[T any](p *AtomicPair[T]) {
+	for {
+		old := p.v.Load()
+		var swapped [2]T
+		if old != nil {
+			swapped = [2]T{old[1], old[0]}
+		}
+		if p.v.CompareAndSwap(old, &swapped) {
+			return
+		}
+	}
+}
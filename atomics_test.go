@@ -0,0 +1,123 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+/*----- Extension: Atomic Pointer Updates -----*/
+
+// hammer runs fn from workers goroutines at once, each calling it times
+// times, and waits for all of them
+func hammer(workers, times int, fn func()) {
+	var start, done sync.WaitGroup
+	start.Add(1)
+	for range workers {
+		done.Go(func() {
+			start.Wait() // release every goroutine together to maximise contention
+			for range times {
+				fn()
+			}
+		})
+	}
+	start.Done()
+	done.Wait()
+}
+
+// 1. CompareAndUpdate
+func TestCompareAndUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		workers int
+		times   int
+		start   int64
+		fn      func(int64) int64
+		want    int64
+	}{
+		{name: "increments are never lost", workers: 64, times: 1000, start: 0, fn: func(v int64) int64 { return v + 1 }, want: 64_000},
+		{name: "decrements below zero", workers: 8, times: 500, start: 100, fn: func(v int64) int64 { return v - 3 }, want: 100 - 8*500*3},
+		{name: "single goroutine", workers: 1, times: 10, start: 1, fn: func(v int64) int64 { return v * 3 }, want: 59049},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var x atomic.Int64
+			x.Store(tt.start)
+			hammer(tt.workers, tt.times, func() { CompareAndUpdate(&x, tt.fn) })
+			if got := x.Load(); got != tt.want {
+				t.Errorf("after %d concurrent updates x = %d, want %d", tt.workers*tt.times, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("returns the stored value", func(t *testing.T) {
+		var x atomic.Int64
+		x.Store(4)
+		if got := CompareAndUpdate(&x, func(v int64) int64 { return v + 6 }); got != 10 || x.Load() != 10 {
+			t.Errorf("CompareAndUpdate() = %d with x = %d, want 10", got, x.Load())
+		}
+	})
+}
+
+// 2. AtomicDouble
+func TestAtomicDouble(t *testing.T) {
+	// 62 doublings of 1 reach 2^62 exactly; a lost update would leave a
+	// smaller power of two
+	var x atomic.Int64
+	x.Store(1)
+	hammer(62, 1, func() { AtomicDouble(&x) })
+	if got := x.Load(); got != 1<<62 {
+		t.Errorf("after 62 concurrent doublings x = %d, want %d", got, int64(1)<<62)
+	}
+
+}
+
+// 3. AtomicSwap
+func TestAtomicSwap(t *testing.T) {
+	pair := NewAtomicPair("left", "right")
+
+	// An even number of swaps restores the original order, and readers
+	// running alongside must never see a half-finished swap
+	var torn atomic.Int64
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	wg.Go(func() {
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			if a, b := pair.Load(); a == b {
+				torn.Add(1)
+			}
+		}
+	})
+	hammer(32, 1000, func() { AtomicSwap(pair) })
+	close(stop)
+	wg.Wait()
+
+	if a, b := pair.Load(); a != "left" || b != "right" {
+		t.Errorf("after 32000 swaps pair = (%q, %q), want (left, right)", a, b)
+	}
+	if n := torn.Load(); n != 0 {
+		t.Errorf("readers saw %d torn pairs", n)
+	}
+
+	t.Run("odd number of swaps", func(t *testing.T) {
+		p := NewAtomicPair(1, 2)
+		hammer(3, 1, func() { AtomicSwap(p) })
+		if a, b := p.Load(); a != 2 || b != 1 {
+			t.Errorf("after 3 swaps pair = (%d, %d), want (2, 1)", a, b)
+		}
+	})
+
+	t.Run("zero value", func(t *testing.T) {
+		var p AtomicPair[int]
+		AtomicSwap(&p)
+		if a, b := p.Load(); a != 0 || b != 0 {
+			t.Errorf("zero pair after swap = (%d, %d), want (0, 0)", a, b)
+		}
+	})
+}
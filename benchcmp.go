@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+/*----- Extension: Benchmark Comparison -----*/
+
+// BenchResult is one benchmark averaged over every run in a file
+type BenchResult struct {
+	Name    string
+	Runs    int                // lines seen for this benchmark, e.g. from -count
+	Metrics map[string]float64 // mean value per unit, e.g. "ns/op" or "allocs/op"
+}
+
+// BenchDelta compares one metric of one benchmark across two files
+type BenchDelta struct {
+	Name     string
+	Unit     string
+	Old, New float64
+	Present  string // "both", "old" or "new"
+}
+
+// benchLine matches "BenchmarkApply/size=10-8   1000000   156.0 ns/op ..."
+var benchLine = regexp.MustCompile(`^(Benchmark\S+)\s+(\d+)\s+(.+)$`)
+
+// procsSuffix matches the -GOMAXPROCS suffix go test appends to names,
+// which it leaves off when GOMAXPROCS is 1
+var procsSuffix = regexp.MustCompile(`-(?:[2-9]|[1-9]\d+)$`)
+
+// 1. ParseBenchmarks - reads `go test -bench` output, ignoring every line
+// that is not a result and dropping the -GOMAXPROCS suffix from names so
+// runs from different machines line up
+// A name like "prime=2^31-1" ends in digits of its own, so the suffix is
+// only dropped when every result line carries the same one.
+func ParseBenchmarks(r io.Reader) ([]BenchResult, error) {
+	var results []BenchResult
+	index := make(map[string]int)
+	suffix, lines := "", 0
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		m := benchLine.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if m == nil {
+			continue
+		}
+
+		// The rest of the line is a list of "value unit" pairs
+		fields := strings.Fields(m[3])
+		if len(fields)%2 != 0 {
+			return nil, fmt.Errorf("malformed benchmark line %q", scanner.Text())
+		}
+		if s := procsSuffix.FindString(m[1]); lines == 0 {
+			suffix = s
+		} else if s != suffix {
+			suffix = ""
+		}
+		lines++
+
+		metrics := make(map[string]float64, len(fields)/2)
+		for i := 0; i < len(fields); i += 2 {
+			value, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("malformed benchmark line %q", scanner.Text())
+			}
+			metrics[fields[i+1]] = value
+		}
+
+		i, ok := index[m[1]]
+		if !ok {
+			i = len(results)
+			index[m[1]] = i
+			results = append(results, BenchResult{Name: m[1], Metrics: make(map[string]float64)})
+		}
+		results[i].add(metrics)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if suffix != "" {
+		for i := range results {
+			results[i].Name = strings.TrimSuffix(results[i].Name, suffix)
+		}
+	}
+	return results, nil
+}
+
+// add folds one more run into the running means
+func (b *BenchResult) add(metrics map[string]float64) {
+	b.Runs++
+	for unit, value := range metrics {
+		b.Metrics[unit] += (value - b.Metrics[unit]) / float64(b.Runs)
+	}
+}
+
+// 2. CompareBenchmarks - pairs up old and new results by name and unit, in
+// the order of the old file followed by benchmarks that only exist in new
+func CompareBenchmarks(before, after []BenchResult) []BenchDelta {
+	newByName := make(map[string]BenchResult, len(after))
+	for _, b := range after {
+		newByName[b.Name] = b
+	}
+
+	var deltas []BenchDelta
+	seen := make(map[string]bool)
+	for _, o := range before {
+		seen[o.Name] = true
+		n, ok := newByName[o.Name]
+		if !ok {
+			for _, unit := range benchUnits(o) {
+				deltas = append(deltas, BenchDelta{Name: o.Name, Unit: unit, Old: o.Metrics[unit], Present: "old"})
+			}
+			continue
+		}
+		for _, unit := range benchUnits(o) {
+			if value, ok := n.Metrics[unit]; ok {
+				deltas = append(deltas, BenchDelta{Name: o.Name, Unit: unit, Old: o.Metrics[unit], New: value, Present: "both"})
+			}
+		}
+	}
+	for _, n := range after {
+		if !seen[n.Name] {
+			for _, unit := range benchUnits(n) {
+				deltas = append(deltas, BenchDelta{Name: n.Name, Unit: unit, New: n.Metrics[unit], Present: "new"})
+			}
+		}
+	}
+	return deltas
+}
+
+// Change formats the relative difference like "+12.50%", or "~" when
+// there is nothing to compare
+func (d BenchDelta) Change() string {
+	switch {
+	case d.Present != "both" || d.Old == d.New:
+		return "~"
+	case d.Old == 0:
+		return "+inf%"
+	}
+	return fmt.Sprintf("%+.2f%%", (d.New-d.Old)/d.Old*100)
+}
+
+// benchUnits lists the units of a result with the standard ones first
+func benchUnits(b BenchResult) []string {
+	var units []string
+	for _, unit := range []string{"ns/op", "B/op", "allocs/op"} {
+		if _, ok := b.Metrics[unit]; ok {
+			units = append(units, unit)
+		}
+	}
+	var custom []string
+	for unit := range b.Metrics {
+		if unit != "ns/op" && unit != "B/op" && unit != "allocs/op" {
+			custom = append(custom, unit)
+		}
+	}
+	sort.Strings(custom)
+	return append(units, custom...)
+}
+
+// runBenchCompare handles `analyzer bench-compare <old.txt> <new.txt>`
+func runBenchCompare(args []string, stdout io.Writer) error {
+	if len(args) != 2 {
+		return fmt.Errorf("%w: expected two benchmark output files", errUsage)
+	}
+
+	var results [2][]BenchResult
+	for i, path := range args {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		results[i], err = ParseBenchmarks(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if len(results[i]) == 0 {
+			return fmt.Errorf("%s: no benchmark results found", path)
+		}
+	}
+
+	tw := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "BENCHMARK\tUNIT\tOLD\tNEW\tDELTA")
+	for _, d := range CompareBenchmarks(results[0], results[1]) {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", d.Name, d.Unit,
+			formatBenchValue(d.Old, d.Present != "new"), formatBenchValue(d.New, d.Present != "old"), d.Change())
+	}
+	return tw.Flush()
+}
+
+// formatBenchValue prints a metric with enough precision for small timings
+func formatBenchValue(value float64, present bool) string {
+	if !present {
+		return "-"
+	}
+	if value == math.Trunc(value) && math.Abs(value) < 1e15 {
+		return strconv.FormatFloat(value, 'f', 0, 64)
+	}
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+/*----- Extension: Benchmark Comparison -----*/
+
+// 1. ParseBenchmarks
+func TestParseBenchmarks(t *testing.T) {
+	f, err := os.Open("testdata/bench/old.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	results, err := ParseBenchmarks(f)
+	if err != nil {
+		t.Fatalf("ParseBenchmarks() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		bench  string
+		runs   int
+		unit   string
+		want   float64
+		absent bool
+	}{
+		{name: "runs are averaged", bench: "BenchmarkFilter/size=1000", runs: 2, unit: "ns/op", want: 4500},
+		{name: "allocations", bench: "BenchmarkFilter/size=1000", runs: 2, unit: "allocs/op", want: 12},
+		{name: "fractional timings", bench: "BenchmarkPointers/CreateOnHeap", runs: 1, unit: "ns/op", want: 21.38},
+		{name: "without ReportAllocs", bench: "BenchmarkTryAll", runs: 1, unit: "B/op", absent: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, r := range results {
+				if r.Name != tt.bench {
+					continue
+				}
+				value, ok := r.Metrics[tt.unit]
+				if r.Runs != tt.runs || ok == tt.absent || value != tt.want {
+					t.Errorf("%s = runs %d, %s %v (present %v), want runs %d, %v", tt.bench, r.Runs, tt.unit, value, ok, tt.runs, tt.want)
+				}
+				return
+			}
+			t.Errorf("ParseBenchmarks() has no result named %q", tt.bench)
+		})
+	}
+
+	if len(results) != 4 {
+		t.Errorf("ParseBenchmarks() returned %d results, want 4", len(results))
+	}
+}
+
+func TestParseBenchmarksMalformed(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{name: "empty", input: "", want: 0},
+		{name: "only headers", input: "goos: linux\nPASS\n", want: 0},
+		{name: "no suffix", input: "BenchmarkX 10 5 ns/op\n", want: 1},
+		{name: "odd fields", input: "BenchmarkX-4 10 5 ns/op 3\n", wantErr: true},
+		{name: "bad number", input: "BenchmarkX-4 10 fast ns/op\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := ParseBenchmarks(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseBenchmarks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && len(results) != tt.want {
+				t.Errorf("ParseBenchmarks() = %+v, want %d results", results, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseBenchmarksNames(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "GOMAXPROCS suffix is dropped",
+			input: "BenchmarkIsPrime/prime=2^31-1-8 10 5 ns/op\nBenchmarkTryAll-8 10 5 ns/op\n",
+			want:  []string{"BenchmarkIsPrime/prime=2^31-1", "BenchmarkTryAll"},
+		},
+		{
+			name:  "-cpu 1 keeps digits in the name",
+			input: "BenchmarkIsPrime/prime=2^31-1 10 5 ns/op\nBenchmarkTryAll 10 5 ns/op\n",
+			want:  []string{"BenchmarkIsPrime/prime=2^31-1", "BenchmarkTryAll"},
+		},
+		{
+			name:  "-cpu 1 with only digit-suffixed names",
+			input: "BenchmarkIsPrime/prime=2^31-1 10 5 ns/op\n",
+			want:  []string{"BenchmarkIsPrime/prime=2^31-1"},
+		},
+		{
+			name:  "-cpu 1,4 keeps the runs apart",
+			input: "BenchmarkX 10 5 ns/op\nBenchmarkX-4 10 3 ns/op\n",
+			want:  []string{"BenchmarkX", "BenchmarkX-4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := ParseBenchmarks(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("ParseBenchmarks() error = %v", err)
+			}
+			var got []string
+			for _, r := range results {
+				got = append(got, r.Name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ParseBenchmarks() names = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// 2. CompareBenchmarks
+func TestCompareBenchmarks(t *testing.T) {
+	before := []BenchResult{
+		{Name: "BenchmarkA", Metrics: map[string]float64{"ns/op": 100, "allocs/op": 2}},
+		{Name: "BenchmarkGone", Metrics: map[string]float64{"ns/op": 5}},
+	}
+	after := []BenchResult{
+		{Name: "BenchmarkAdded", Metrics: map[string]float64{"ns/op": 7}},
+		{Name: "BenchmarkA", Metrics: map[string]float64{"ns/op": 125, "allocs/op": 0, "MB/s": 3}},
+	}
+
+	want := []struct {
+		name, unit, change, present string
+	}{
+		{name: "BenchmarkA", unit: "ns/op", change: "+25.00%", present: "both"},
+		{name: "BenchmarkA", unit: "allocs/op", change: "-100.00%", present: "both"},
+		{name: "BenchmarkGone", unit: "ns/op", change: "~", present: "old"},
+		{name: "BenchmarkAdded", unit: "ns/op", change: "~", present: "new"},
+	}
+
+	got := CompareBenchmarks(before, after)
+	if len(got) != len(want) {
+		t.Fatalf("CompareBenchmarks() = %+v, want %d deltas", got, len(want))
+	}
+	for i, w := range want {
+		d := got[i]
+		if d.Name != w.name || d.Unit != w.unit || d.Change() != w.change || d.Present != w.present {
+			t.Errorf("delta %d = %+v (%s), want %+v", i, d, d.Change(), w)
+		}
+	}
+
+	if change := (BenchDelta{Old: 0, New: 8, Present: "both"}).Change(); change != "+inf%" {
+		t.Errorf("Change() from zero = %q, want +inf%%", change)
+	}
+}
+
+func TestRunBenchCompare(t *testing.T) {
+	var stdout strings.Builder
+	err := runBenchCompare([]string{"testdata/bench/old.txt", "testdata/bench/new.txt"}, &stdout)
+	if err != nil {
+		t.Fatalf("runBenchCompare() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	wantLines := [][]string{
+		{"BENCHMARK", "UNIT", "OLD", "NEW", "DELTA"},
+		{"BenchmarkFilter/size=1000", "ns/op", "4500", "3000", "-33.33%"},
+		{"BenchmarkFilter/size=1000", "allocs/op", "12", "1", "-91.67%"},
+		{"BenchmarkIsPrime/prime=97", "ns/op", "110.50", "-", "~"},
+		{"BenchmarkPipeline/stages=10/size=10", "ns/op", "-", "300.40", "~"},
+	}
+	for _, want := range wantLines {
+		found := false
+		for _, line := range lines {
+			found = found || strings.Join(strings.Fields(line), " ") == strings.Join(want, " ")
+		}
+		if !found {
+			t.Errorf("runBenchCompare() output is missing %q:\n%s", want, stdout.String())
+		}
+	}
+
+	if err := runBenchCompare([]string{"testdata/bench/old.txt"}, &stdout); !errors.Is(err, errUsage) {
+		t.Errorf("runBenchCompare() with one file error = %v, want a usage error", err)
+	}
+	if err := runBenchCompare([]string{"testdata/bench/old.txt", "testdata/bench/missing.txt"}, &stdout); err == nil {
+		t.Error("runBenchCompare() with a missing file succeeded")
+	}
+	if err := runBenchCompare([]string{"testdata/bench/old.txt", "go.mod"}, &stdout); err == nil {
+		t.Error("runBenchCompare() with a file without results succeeded")
+	}
+}
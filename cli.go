@@ -0,0 +1,244 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*----- Extension: Command-Line Calculator -----*/
+
+// Exit codes returned by run
+const (
+	exitOK    = 0
+	exitError = 1 // the computation itself failed
+	exitUsage = 2 // the command line could not be understood
+)
+
+// errUsage marks errors caused by bad arguments rather than bad math
+var errUsage = errors.New("invalid arguments")
+
+// command is a single analyzer subcommand
+type command struct {
+	usage string
+	run   func(args []string, stdout io.Writer) error
+}
+
+// commands maps each subcommand name to its implementation
+var commands = map[string]command{
+	"bench-compare": {usage: "bench-compare <old.txt> <new.txt>", run: runBenchCompare},
+	"eval":          {usage: "eval <expression>", run: runEval},
+	"copies":        {usage: "copies", run: runCopies},
+	"escape":        {usage: "escape [--format table|json] [--kind <kind,...>] <package-dir>", run: runEscape},
+	"factorial":     {usage: "factorial <n>", run: runFactorial},
+	"grpc":          {usage: "grpc [--addr <host:port>]", run: runGRPC},
+	"isprime":       {usage: "isprime <n>", run: runIsPrime},
+	"layout":        {usage: "layout [--arch <arch>] [--padded] <package-dir> [type...]", run: runLayout},
+	"memstats":      {usage: "memstats [--runs <n>] stack|heap|apply|filter|pipeline|primes", run: runMemstats},
+	"power":         {usage: "power <base> <exponent>", run: runPower},
+	"primes":        {usage: "primes --upto <n>", run: runPrimes},
+	"ps":            {usage: "ps [--tree] [--user <name|uid>] [--name <text>] [--proc <dir>]", run: runPS},
+	"repl":          {usage: "repl [--history <file>]", run: runREPL},
+	"serve":         {usage: "serve [--addr <host:port>]", run: runServe},
+	"slices":        {usage: "slices [--appends <n>]", run: runSlices},
+}
+
+// demoCommand runs when the first argument is a flag instead of a command
+var demoCommand = command{usage: "--format json|text|yaml", run: runDemoReport}
+
+// run dispatches args to a subcommand and returns the process exit code
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage(stdout)
+		return exitOK
+	}
+
+	cmd, ok := commands[args[0]]
+	rest := args[1:]
+	if strings.HasPrefix(args[0], "-") {
+		cmd, ok, rest = demoCommand, true, args
+	}
+	if !ok {
+		fmt.Fprintf(stderr, "analyzer: unknown command %q\n", args[0])
+		printUsage(stderr)
+		return exitUsage
+	}
+
+	if err := cmd.run(rest, stdout); err != nil {
+		fmt.Fprintln(stderr, "analyzer:", err)
+		if errors.Is(err, errUsage) {
+			fmt.Fprintln(stderr, "usage: analyzer", cmd.usage)
+			return exitUsage
+		}
+		return exitError
+	}
+	return exitOK
+}
+
+// printUsage lists every subcommand in alphabetical order
+func printUsage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "usage: analyzer <command> [arguments]")
+	fmt.Fprintln(w, "\nRun without a command to print the lab demo, or with")
+	fmt.Fprintln(w, "  analyzer", demoCommand.usage)
+	fmt.Fprintln(w, "to write it to stdout as structured records.")
+	fmt.Fprintln(w, "\nCommands:")
+	for _, name := range names {
+		fmt.Fprintln(w, "  analyzer", commands[name].usage)
+	}
+}
+
+// runFactorial handles `analyzer factorial <n>`
+func runFactorial(args []string, stdout io.Writer) error {
+	nums, err := parseInts(args, 1)
+	if err != nil {
+		return err
+	}
+
+	result, err := checkedFactorial(nums[0])
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, result)
+	return nil
+}
+
+// runIsPrime handles `analyzer isprime <n>`
+func runIsPrime(args []string, stdout io.Writer) error {
+	nums, err := parseInts(args, 1)
+	if err != nil {
+		return err
+	}
+
+	result, err := IsPrime(nums[0])
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, result)
+	return nil
+}
+
+// runPower handles `analyzer power <base> <exponent>`
+func runPower(args []string, stdout io.Writer) error {
+	nums, err := parseInts(args, 2)
+	if err != nil {
+		return err
+	}
+
+	result, err := checkedPower(nums[0], nums[1])
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, result)
+	return nil
+}
+
+// runPrimes handles `analyzer primes --upto <n>`, one prime per line
+func runPrimes(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("primes", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	upto := flags.Int("upto", -1, "largest number to consider")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if flags.NArg() != 0 || *upto < 0 {
+		return fmt.Errorf("%w: --upto <n> is required", errUsage)
+	}
+
+	primes, err := PrimesUpTo(*upto)
+	if err != nil {
+		return err
+	}
+	for _, p := range primes {
+		fmt.Fprintln(stdout, p)
+	}
+	return nil
+}
+
+// runEval handles `analyzer eval "map(x*x, 1..5) | reduce(+)"`
+func runEval(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: expected an expression", errUsage)
+	}
+
+	// Allow unquoted expressions split across several arguments
+	result, err := EvalExpr(strings.Join(args, " "))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, FormatValue(result))
+	return nil
+}
+
+// checkedFactorial calls Factorial after verifying n! fits in an int
+// Factorial wraps silently past 20!, which a script would never notice.
+func checkedFactorial(n int) (int, error) {
+	product := 1
+	for i := 2; i <= n; i++ {
+		next, ok := checkedMul(product, i)
+		if !ok {
+			return 0, errors.New("factorial result overflows int")
+		}
+		product = next
+	}
+	return Factorial(n)
+}
+
+// checkedPower calls Power after verifying the result fits in an int
+// Power also wraps silently, and loops once per unit of exponent, so
+// bases of magnitude <= 1 get an equivalent small exponent.
+func checkedPower(base, exponent int) (int, error) {
+	if base < -1 || base > 1 {
+		// Track the magnitude unsigned so MinInt has one, and let an odd
+		// power of a negative base reach MinInt's magnitude of MaxInt+1
+		abs := uint64(base)
+		if base < 0 {
+			abs = -abs
+		}
+		limit := uint64(math.MaxInt)
+		if base < 0 && exponent%2 == 1 {
+			limit++
+		}
+
+		magnitude := uint64(1)
+		for i := 0; i < exponent; i++ {
+			hi, lo := bits.Mul64(magnitude, abs)
+			if hi != 0 || lo > limit {
+				return 0, errors.New("power result overflows int")
+			}
+			magnitude = lo
+		}
+	} else if exponent > 2 {
+		// 0, 1 and -1 only depend on whether the exponent is odd or even
+		exponent = 2 - exponent%2
+	}
+	return Power(base, exponent)
+}
+
+// parseInts parses exactly want integer arguments
+func parseInts(args []string, want int) ([]int, error) {
+	if len(args) != want {
+		return nil, fmt.Errorf("%w: expected %d argument(s), got %d", errUsage, want, len(args))
+	}
+
+	nums := make([]int, want)
+	for i, arg := range args {
+		n, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q is not an integer", errUsage, arg)
+		}
+		nums[i] = n
+	}
+	return nums, nil
+}
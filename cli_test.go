@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+/*----- Extension: Command-Line Calculator -----*/
+
+// TestRun tests subcommand dispatch, output and exit codes
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantStdout string
+		wantStderr string
+		needs64    bool
+	}{
+		{name: "factorial 20", args: []string{"factorial", "20"}, wantCode: exitOK, wantStdout: "2432902008176640000\n", needs64: true},
+		{name: "factorial overflow", args: []string{"factorial", "21"}, wantCode: exitError, wantStderr: "overflows"},
+		{name: "factorial huge overflows quickly", args: []string{"factorial", "20000000"}, wantCode: exitError, wantStderr: "overflows"},
+		{name: "factorial negative", args: []string{"factorial", "-3"}, wantCode: exitError, wantStderr: "negative numbers"},
+		{name: "isprime 97", args: []string{"isprime", "97"}, wantCode: exitOK, wantStdout: "true\n"},
+		{name: "isprime 100", args: []string{"isprime", "100"}, wantCode: exitOK, wantStdout: "false\n"},
+		{name: "isprime 1", args: []string{"isprime", "1"}, wantCode: exitError, wantStderr: ">= 2"},
+		{name: "power 2 62", args: []string{"power", "2", "62"}, wantCode: exitOK, wantStdout: "4611686018427387904\n", needs64: true},
+		{name: "power 2 64 overflows", args: []string{"power", "2", "64"}, wantCode: exitError, wantStderr: "overflows"},
+		{name: "power negative exponent", args: []string{"power", "2", "-1"}, wantCode: exitError, wantStderr: "negative exponents"},
+		{name: "primes upto 20", args: []string{"primes", "--upto", "20"}, wantCode: exitOK, wantStdout: "2\n3\n5\n7\n11\n13\n17\n19\n"},
+		{name: "primes upto 1", args: []string{"primes", "--upto=1"}, wantCode: exitOK, wantStdout: ""},
+		{name: "primes upto beyond sieve bound", args: []string{"primes", "--upto", strconv.Itoa(math.MaxInt - 1)}, wantCode: exitError, wantStderr: "at most"},
+		{name: "primes missing flag", args: []string{"primes"}, wantCode: exitUsage, wantStderr: "--upto"},
+		{name: "non-integer argument", args: []string{"factorial", "five"}, wantCode: exitUsage, wantStderr: "not an integer"},
+		{name: "too many arguments", args: []string{"isprime", "3", "5"}, wantCode: exitUsage, wantStderr: "usage: analyzer isprime"},
+		{name: "unknown command", args: []string{"sqrt", "4"}, wantCode: exitUsage, wantStderr: "unknown command"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.needs64 && strconv.IntSize < 64 {
+				t.Skip("result does not fit in a 32-bit int")
+			}
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, &stdout, &stderr)
+			if code != tt.wantCode {
+				t.Errorf("run(%v) exit code = %d, want %d (stderr: %s)", tt.args, code, tt.wantCode, stderr.String())
+			}
+			if stdout.String() != tt.wantStdout {
+				t.Errorf("run(%v) stdout = %q, want %q", tt.args, stdout.String(), tt.wantStdout)
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("run(%v) stderr = %q, want it to contain %q", tt.args, stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+
+	t.Run("help lists every command", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		if code := run([]string{"help"}, &stdout, &stderr); code != exitOK {
+			t.Errorf("run(help) exit code = %d, want %d", code, exitOK)
+		}
+		for _, cmd := range commands {
+			if !strings.Contains(stdout.String(), cmd.usage) {
+				t.Errorf("run(help) output is missing %q", cmd.usage)
+			}
+		}
+	})
+}
+
+// TestCheckedArithmetic tests the overflow guards shared by the CLI and API
+func TestCheckedArithmetic(t *testing.T) {
+	tests := []struct {
+		name    string
+		fn      func() (int, error)
+		want    int64
+		wantErr bool
+	}{
+		{name: "factorial 20 fits", fn: func() (int, error) { return checkedFactorial(20) }, want: 2432902008176640000},
+		{name: "factorial 21 overflows", fn: func() (int, error) { return checkedFactorial(21) }, wantErr: true},
+		{name: "factorial of a huge n returns quickly", fn: func() (int, error) { return checkedFactorial(math.MaxInt) }, wantErr: true},
+		{name: "power 3^39 fits", fn: func() (int, error) { return checkedPower(3, 39) }, want: 4052555153018976267},
+		{name: "power 3^40 overflows", fn: func() (int, error) { return checkedPower(3, 40) }, wantErr: true},
+		{name: "negative base", fn: func() (int, error) { return checkedPower(-2, 3) }, want: -8},
+		{name: "power of two one bit short of int", fn: func() (int, error) { return checkedPower(2, strconv.IntSize-1) }, wantErr: true},
+		{name: "power of minus two reaching MinInt", fn: func() (int, error) { return checkedPower(-2, strconv.IntSize-1) }, want: math.MinInt},
+		{name: "power of minus two past MinInt", fn: func() (int, error) { return checkedPower(-2, strconv.IntSize) }, wantErr: true},
+		{name: "MinInt to the first", fn: func() (int, error) { return checkedPower(math.MinInt, 1) }, want: math.MinInt},
+		{name: "MinInt squared overflows", fn: func() (int, error) { return checkedPower(math.MinInt, 2) }, wantErr: true},
+		{name: "MinInt cubed overflows", fn: func() (int, error) { return checkedPower(math.MinInt, 3) }, wantErr: true},
+		{name: "one to a huge exponent", fn: func() (int, error) { return checkedPower(1, math.MaxInt-1) }, want: 1},
+		{name: "minus one to an odd huge exponent", fn: func() (int, error) { return checkedPower(-1, math.MaxInt) }, want: -1},
+		{name: "zero to a huge exponent", fn: func() (int, error) { return checkedPower(0, math.MaxInt) }, want: 0},
+		{name: "negative exponent", fn: func() (int, error) { return checkedPower(2, -1) }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if is64BitOnly(tt.want) {
+				t.Skipf("%d does not fit in a %d-bit int", tt.want, strconv.IntSize)
+			}
+			got, err := tt.fn()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if int64(got) != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
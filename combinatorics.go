@@ -0,0 +1,286 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+/*----- Extension: Gamma Function & Combinatorics -----*/
+
+// 1. GammaFactorial - calculates x! for real x using Gamma(x+1)
+// Agrees with Factorial for non-negative integers and extends it to
+// fractional inputs, e.g. GammaFactorial(0.5) = sqrt(pi)/2.
+func GammaFactorial(x float64) (float64, error) {
+	if math.IsNaN(x) {
+		return 0, errors.New("factorial is not defined for NaN")
+	}
+	// Gamma has poles at 0, -1, -2, ... so x! is undefined for negative integers
+	if x < 0 && x == math.Trunc(x) {
+		return 0, errors.New("factorial is not defined for negative integers")
+	}
+
+	result := math.Gamma(x + 1)
+	if math.IsInf(result, 0) {
+		return 0, errors.New("factorial overflows float64, use LogFactorial")
+	}
+	return result, nil
+}
+
+// 2. LogFactorial - calculates ln(n!) so huge n can be handled in log-space
+func LogFactorial(n int) (float64, error) {
+	if n < 0 {
+		return 0, errors.New("factorial is not defined for negative numbers")
+	}
+
+	result, _ := math.Lgamma(float64(n) + 1)
+	return result, nil
+}
+
+// 3. Choose - calculates the binomial coefficient C(n, k)
+// Builds the result one factor at a time instead of dividing full factorials,
+// so it only fails when C(n, k) itself does not fit in an int.
+func Choose(n, k int) (int, error) {
+	if n < 0 || k < 0 {
+		return 0, errors.New("choose requires non-negative n and k")
+	}
+	if k > n {
+		return 0, nil
+	}
+
+	// C(n, k) == C(n, n-k), use the shorter loop
+	if k > n-k {
+		k = n - k
+	}
+
+	result := 1
+	for i := 1; i <= k; i++ {
+		// result * (n-k+i) is always divisible by i; reduce first to avoid
+		// overflowing on the intermediate product
+		g := gcd(result, i)
+		factor := (n - k + i) / (i / g)
+
+		next, ok := checkedMul(result/g, factor)
+		if !ok {
+			return 0, errors.New("choose result overflows int")
+		}
+		result = next
+	}
+	return result, nil
+}
+
+// 4. Permutations - calculates P(n, k) = n! / (n-k)!
+func Permutations(n, k int) (int, error) {
+	if n < 0 || k < 0 {
+		return 0, errors.New("permutations require non-negative n and k")
+	}
+	if k > n {
+		return 0, nil
+	}
+
+	// Count down from n so no loop variable ever exceeds n
+	result := 1
+	for i := 0; i < k; i++ {
+		next, ok := checkedMul(result, n-i)
+		if !ok {
+			return 0, errors.New("permutations result overflows int")
+		}
+		result = next
+	}
+	return result, nil
+}
+
+/*----- Extension: Exact Counting Numbers -----*/
+
+// 1. Binomial - calculates C(n, k) = n! / (k! (n-k)!) without overflowing
+// on the intermediate factorials; see Choose
+func Binomial(n, k int) (int, error) {
+	return Choose(n, k)
+}
+
+// 2. BinomialBig - calculates C(n, k) exactly for any size
+func BinomialBig(n, k int) (*big.Int, error) {
+	if n < 0 || k < 0 {
+		return nil, errors.New("binomial requires non-negative n and k")
+	}
+	if k > n {
+		return big.NewInt(0), nil
+	}
+	return new(big.Int).Binomial(int64(n), int64(k)), nil
+}
+
+// 3. Multinomial - calculates (k1+k2+...)! / (k1! k2! ...)
+// Computed as a product of binomials C(k1+...+ki, ki) so only the final
+// result has to fit in an int.
+func Multinomial(ks ...int) (int, error) {
+	result, err := MultinomialBig(ks...)
+	if err != nil {
+		return 0, err
+	}
+	return bigToInt(result, "multinomial")
+}
+
+// 4. MultinomialBig - calculates the multinomial coefficient exactly
+func MultinomialBig(ks ...int) (*big.Int, error) {
+	result := big.NewInt(1)
+	total := 0
+	for _, k := range ks {
+		if k < 0 {
+			return nil, errors.New("multinomial requires non-negative counts")
+		}
+		if total > math.MaxInt-k {
+			return nil, errors.New("multinomial total overflows int")
+		}
+		total += k
+		result.Mul(result, new(big.Int).Binomial(int64(total), int64(k)))
+	}
+	return result, nil
+}
+
+// 5. Catalan - calculates the nth Catalan number C(2n, n) / (n+1)
+func Catalan(n int) (int, error) {
+	if n < 0 {
+		return 0, errors.New("catalan is not defined for negative numbers")
+	}
+
+	// C(i+1) = C(i) * 2(2i+1) / (i+2), reduced like Choose to stay in range
+	result := 1
+	for i := 0; i < n; i++ {
+		g := gcd(result, i+2)
+		factor := 2 * (2*i + 1) / ((i + 2) / g)
+
+		next, ok := checkedMul(result/g, factor)
+		if !ok {
+			return 0, errors.New("catalan result overflows int")
+		}
+		result = next
+	}
+	return result, nil
+}
+
+// 6. CatalanBig - calculates the nth Catalan number exactly
+func CatalanBig(n int) (*big.Int, error) {
+	if n < 0 {
+		return nil, errors.New("catalan is not defined for negative numbers")
+	}
+
+	result := new(big.Int).Binomial(int64(2*n), int64(n))
+	return result.Quo(result, big.NewInt(int64(n+1))), nil
+}
+
+// 7. StirlingFirst - calculates the unsigned Stirling number of the first kind
+// c(n, k): the number of permutations of n elements with k cycles
+func StirlingFirst(n, k int) (int, error) {
+	result, err := StirlingFirstBig(n, k)
+	if err != nil {
+		return 0, err
+	}
+	return bigToInt(result, "stirling number")
+}
+
+// 8. StirlingFirstBig - calculates c(n, k) exactly
+func StirlingFirstBig(n, k int) (*big.Int, error) {
+	if n < 0 || k < 0 {
+		return nil, errors.New("stirling numbers require non-negative n and k")
+	}
+
+	// row[j] holds c(i, j); c(i+1, j) = i*c(i, j) + c(i, j-1)
+	row := stirlingRow(n, func(i, j int) int64 { return int64(i) })
+	if k > n {
+		return big.NewInt(0), nil
+	}
+	return row[k], nil
+}
+
+// 9. StirlingSecond - calculates the Stirling number of the second kind
+// S(n, k): the number of ways to partition n elements into k non-empty sets
+func StirlingSecond(n, k int) (int, error) {
+	result, err := StirlingSecondBig(n, k)
+	if err != nil {
+		return 0, err
+	}
+	return bigToInt(result, "stirling number")
+}
+
+// 10. StirlingSecondBig - calculates S(n, k) exactly
+func StirlingSecondBig(n, k int) (*big.Int, error) {
+	if n < 0 || k < 0 {
+		return nil, errors.New("stirling numbers require non-negative n and k")
+	}
+
+	// row[j] holds S(i, j); S(i+1, j) = j*S(i, j) + S(i, j-1)
+	row := stirlingRow(n, func(i, j int) int64 { return int64(j) })
+	if k > n {
+		return big.NewInt(0), nil
+	}
+	return row[k], nil
+}
+
+// 11. Bell - calculates the nth Bell number: partitions of a set of n elements
+func Bell(n int) (int, error) {
+	result, err := BellBig(n)
+	if err != nil {
+		return 0, err
+	}
+	return bigToInt(result, "bell number")
+}
+
+// 12. BellBig - calculates the nth Bell number exactly as the sum of S(n, k)
+func BellBig(n int) (*big.Int, error) {
+	if n < 0 {
+		return nil, errors.New("bell numbers are not defined for negative numbers")
+	}
+
+	result := new(big.Int)
+	for _, s := range stirlingRow(n, func(i, j int) int64 { return int64(j) }) {
+		result.Add(result, s)
+	}
+	return result, nil
+}
+
+// stirlingRow builds row n of a Stirling triangle where
+// T(i+1, j) = weight(i, j)*T(i, j) + T(i, j-1) and T(0, 0) = 1
+func stirlingRow(n int, weight func(i, j int) int64) []*big.Int {
+	row := []*big.Int{big.NewInt(1)}
+	for i := 0; i < n; i++ {
+		next := make([]*big.Int, i+2)
+		for j := range next {
+			next[j] = new(big.Int)
+			if j <= i {
+				next[j].Mul(row[j], big.NewInt(weight(i, j)))
+			}
+			if j > 0 {
+				next[j].Add(next[j], row[j-1])
+			}
+		}
+		row = next
+	}
+	return row
+}
+
+// bigToInt converts an exact result to an int, reporting overflow
+func bigToInt(v *big.Int, what string) (int, error) {
+	if !v.IsInt64() || v.Int64() > math.MaxInt {
+		return 0, errors.New(what + " result overflows int")
+	}
+	return int(v.Int64()), nil
+}
+
+// gcd returns the greatest common divisor of two non-negative ints
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// checkedMul multiplies two non-negative ints and reports whether the
+// product fits in an int
+func checkedMul(a, b int) (int, bool) {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	if hi != 0 || lo > math.MaxInt {
+		return 0, false
+	}
+	return int(lo), true
+}
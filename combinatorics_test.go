@@ -0,0 +1,371 @@
+package main
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+/*----- Extension: Gamma Function & Combinatorics -----*/
+
+// 1. GammaFactorial
+func TestGammaFactorial(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   float64
+		want    float64
+		wantErr bool
+	}{
+		{name: "factorial of 0", input: 0, want: 1, wantErr: false},
+		{name: "factorial of 5", input: 5, want: 120, wantErr: false},
+		{name: "factorial of 10", input: 10, want: 3628800, wantErr: false},
+		{name: "factorial of 0.5", input: 0.5, want: math.Sqrt(math.Pi) / 2, wantErr: false},
+		{name: "factorial of -0.5", input: -0.5, want: math.Sqrt(math.Pi), wantErr: false},
+		{name: "negative integer -1", input: -1, want: 0, wantErr: true},
+		{name: "negative integer -4", input: -4, want: 0, wantErr: true},
+		{name: "overflow at 200", input: 200, want: 0, wantErr: true},
+		{name: "NaN", input: math.NaN(), want: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GammaFactorial(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GammaFactorial() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if math.Abs(got-tt.want) > 1e-9*math.Max(1, math.Abs(tt.want)) {
+				t.Errorf("GammaFactorial() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// 2. LogFactorial
+func TestLogFactorial(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   int
+		want    float64
+		wantErr bool
+	}{
+		{name: "log factorial of 0", input: 0, want: 0, wantErr: false},
+		{name: "log factorial of 1", input: 1, want: 0, wantErr: false},
+		{name: "log factorial of 10", input: 10, want: math.Log(3628800), wantErr: false},
+		{name: "log factorial of 1000", input: 1000, want: 5912.128178488163, wantErr: false},
+		{name: "negative number -1", input: -1, want: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LogFactorial(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LogFactorial() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if math.Abs(got-tt.want) > 1e-9*math.Max(1, tt.want) {
+				t.Errorf("LogFactorial() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// 3. Choose
+func TestChoose(t *testing.T) {
+	tests := []struct {
+		name    string
+		n, k    int
+		want    int64
+		wantErr bool
+	}{
+		{name: "5 choose 2", n: 5, k: 2, want: 10, wantErr: false},
+		{name: "10 choose 0", n: 10, k: 0, want: 1, wantErr: false},
+		{name: "10 choose 10", n: 10, k: 10, want: 1, wantErr: false},
+		{name: "k greater than n", n: 3, k: 5, want: 0, wantErr: false},
+		{name: "25 choose 12 (past 20!)", n: 25, k: 12, want: 5200300, wantErr: false},
+		{name: "62 choose 31", n: 62, k: 31, want: 465428353255261088, wantErr: false},
+		{name: "68 choose 34 overflows", n: 68, k: 34, want: 0, wantErr: true},
+		{name: "negative n", n: -1, k: 0, want: 0, wantErr: true},
+		{name: "negative k", n: 4, k: -2, want: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if is64BitOnly(tt.want) {
+				t.Skipf("%d does not fit in a %d-bit int", tt.want, strconv.IntSize)
+			}
+			got, err := Choose(tt.n, tt.k)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Choose() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if int64(got) != tt.want {
+				t.Errorf("Choose() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// 4. Permutations
+func TestPermutations(t *testing.T) {
+	tests := []struct {
+		name    string
+		n, k    int
+		want    int
+		wantErr bool
+	}{
+		{name: "5 permute 2", n: 5, k: 2, want: 20, wantErr: false},
+		{name: "5 permute 5", n: 5, k: 5, want: 120, wantErr: false},
+		{name: "7 permute 0", n: 7, k: 0, want: 1, wantErr: false},
+		{name: "k greater than n", n: 2, k: 3, want: 0, wantErr: false},
+		{name: "100 permute 3", n: 100, k: 3, want: 970200, wantErr: false},
+		{name: "30 permute 30 overflows", n: 30, k: 30, want: 0, wantErr: true},
+		{name: "MaxInt permute 0", n: math.MaxInt, k: 0, want: 1, wantErr: false},
+		{name: "MaxInt permute 1", n: math.MaxInt, k: 1, want: math.MaxInt, wantErr: false},
+		{name: "MaxInt permute 2 overflows", n: math.MaxInt, k: 2, want: 0, wantErr: true},
+		{name: "negative n", n: -5, k: 1, want: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Permutations(tt.n, tt.k)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Permutations() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Permutations() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+/*----- Extension: Exact Counting Numbers -----*/
+
+// 1. Binomial
+func TestBinomial(t *testing.T) {
+	tests := []struct {
+		name    string
+		n, k    int
+		want    string
+		wantErr bool
+	}{
+		{name: "5 choose 2", n: 5, k: 2, want: "10", wantErr: false},
+		{name: "21 choose 10 (past 20!)", n: 21, k: 10, want: "352716", wantErr: false},
+		{name: "k greater than n", n: 3, k: 4, want: "0", wantErr: false},
+		{name: "100 choose 50 overflows", n: 100, k: 50, want: "100891344545564193334812497256", wantErr: true},
+		{name: "negative n", n: -3, k: 1, want: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Binomial(tt.n, tt.k)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Binomial() error = %v, wantErr %v", err, tt.wantErr)
+			} else if err == nil && strconv.Itoa(got) != tt.want {
+				t.Errorf("Binomial() = %v, want %v", got, tt.want)
+			}
+
+			// The big variant only fails on invalid input, never on size
+			gotBig, err := BinomialBig(tt.n, tt.k)
+			if (err != nil) != (tt.want == "") {
+				t.Errorf("BinomialBig() error = %v", err)
+				return
+			}
+			if err == nil && gotBig.String() != tt.want {
+				t.Errorf("BinomialBig() = %v, want %v", gotBig, tt.want)
+			}
+		})
+	}
+}
+
+// 2. Multinomial
+func TestMultinomial(t *testing.T) {
+	tests := []struct {
+		name    string
+		ks      []int
+		want    string
+		wantErr bool
+	}{
+		{name: "no counts", ks: nil, want: "1", wantErr: false},
+		{name: "single count", ks: []int{7}, want: "1", wantErr: false},
+		{name: "MISSISSIPPI letters", ks: []int{1, 4, 4, 2}, want: "34650", wantErr: false},
+		{name: "binomial case", ks: []int{2, 3}, want: "10", wantErr: false},
+		{name: "10,10,10", ks: []int{10, 10, 10}, want: "5550996791340", wantErr: false},
+		{name: "overflow", ks: []int{20, 20, 20, 20}, want: "", wantErr: true},
+		{name: "negative count", ks: []int{2, -1}, want: "", wantErr: true},
+		{name: "total overflows int", ks: []int{math.MaxInt, 1}, want: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if want, err := strconv.ParseInt(tt.want, 10, 64); err == nil && is64BitOnly(want) {
+				t.Skipf("%d does not fit in a %d-bit int", want, strconv.IntSize)
+			}
+			got, err := Multinomial(tt.ks...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Multinomial() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && strconv.Itoa(got) != tt.want {
+				t.Errorf("Multinomial() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("big variant handles overflow case", func(t *testing.T) {
+		got, err := MultinomialBig(20, 20, 20, 20)
+		if err != nil {
+			t.Fatalf("MultinomialBig() error = %v", err)
+		}
+		want := "2042816020019820636556288572807323741663688000"
+		if got.String() != want {
+			t.Errorf("MultinomialBig() = %v, want %v", got, want)
+		}
+		if _, err := MultinomialBig(math.MaxInt, 1); err == nil {
+			t.Errorf("MultinomialBig(MaxInt, 1) error = nil, want overflow")
+		}
+	})
+}
+
+// 3. Catalan
+func TestCatalan(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   int
+		want    int64
+		wantErr bool
+	}{
+		{name: "catalan 0", input: 0, want: 1, wantErr: false},
+		{name: "catalan 1", input: 1, want: 1, wantErr: false},
+		{name: "catalan 5", input: 5, want: 42, wantErr: false},
+		{name: "catalan 10", input: 10, want: 16796, wantErr: false},
+		{name: "catalan 35 (largest fitting)", input: 35, want: 3116285494907301262, wantErr: false},
+		{name: "catalan 36 overflows", input: 36, want: 0, wantErr: true},
+		{name: "negative number", input: -1, want: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if is64BitOnly(tt.want) {
+				t.Skipf("%d does not fit in a %d-bit int", tt.want, strconv.IntSize)
+			}
+			got, err := Catalan(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Catalan() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if int64(got) != tt.want {
+				t.Errorf("Catalan() = %v, want %v", got, tt.want)
+			}
+			if tt.input >= 0 {
+				gotBig, _ := CatalanBig(tt.input)
+				if !tt.wantErr && gotBig.Int64() != tt.want {
+					t.Errorf("CatalanBig() = %v, want %v", gotBig, tt.want)
+				}
+			}
+		})
+	}
+}
+
+// 4. StirlingFirst & StirlingSecond
+func TestStirling(t *testing.T) {
+	tests := []struct {
+		name       string
+		n, k       int
+		wantFirst  string
+		wantSecond string
+		wantErr    bool
+	}{
+		{name: "0, 0", n: 0, k: 0, wantFirst: "1", wantSecond: "1", wantErr: false},
+		{name: "5, 0", n: 5, k: 0, wantFirst: "0", wantSecond: "0", wantErr: false},
+		{name: "4, 2", n: 4, k: 2, wantFirst: "11", wantSecond: "7", wantErr: false},
+		{name: "10, 3", n: 10, k: 3, wantFirst: "1172700", wantSecond: "9330", wantErr: false},
+		{name: "6, 6", n: 6, k: 6, wantFirst: "1", wantSecond: "1", wantErr: false},
+		{name: "k greater than n", n: 3, k: 4, wantFirst: "0", wantSecond: "0", wantErr: false},
+		{name: "negative k", n: 3, k: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, err := StirlingFirst(tt.n, tt.k)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("StirlingFirst() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			second, err := StirlingSecond(tt.n, tt.k)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("StirlingSecond() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if strconv.Itoa(first) != tt.wantFirst {
+				t.Errorf("StirlingFirst() = %v, want %v", first, tt.wantFirst)
+			}
+			if strconv.Itoa(second) != tt.wantSecond {
+				t.Errorf("StirlingSecond() = %v, want %v", second, tt.wantSecond)
+			}
+		})
+	}
+
+	t.Run("overflow is detected", func(t *testing.T) {
+		// c(25, 1) = 24! and S(30, 15) both exceed int64
+		if _, err := StirlingFirst(25, 1); err == nil {
+			t.Errorf("StirlingFirst(25, 1) error = nil, want overflow")
+		}
+		if _, err := StirlingSecond(30, 15); err == nil {
+			t.Errorf("StirlingSecond(30, 15) error = nil, want overflow")
+		}
+
+		got, _ := StirlingFirstBig(25, 1)
+		if got.String() != "620448401733239439360000" {
+			t.Errorf("StirlingFirstBig(25, 1) = %v, want 620448401733239439360000", got)
+		}
+		got, _ = StirlingSecondBig(30, 15)
+		if got.String() != "12879868072770626040000" {
+			t.Errorf("StirlingSecondBig(30, 15) = %v, want 12879868072770626040000", got)
+		}
+	})
+}
+
+// 5. Bell
+func TestBell(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   int
+		want    int64
+		wantErr bool
+	}{
+		{name: "bell 0", input: 0, want: 1, wantErr: false},
+		{name: "bell 1", input: 1, want: 1, wantErr: false},
+		{name: "bell 5", input: 5, want: 52, wantErr: false},
+		{name: "bell 10", input: 10, want: 115975, wantErr: false},
+		{name: "bell 25 (largest fitting)", input: 25, want: 4638590332229999353, wantErr: false},
+		{name: "bell 26 overflows", input: 26, want: 0, wantErr: true},
+		{name: "negative number", input: -2, want: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if is64BitOnly(tt.want) {
+				t.Skipf("%d does not fit in a %d-bit int", tt.want, strconv.IntSize)
+			}
+			got, err := Bell(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Bell() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if int64(got) != tt.want {
+				t.Errorf("Bell() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// is64BitOnly reports whether a table value is too wide for int, so cases
+// that only hold on 64-bit platforms can be skipped on 32-bit builds
+func is64BitOnly(v int64) bool {
+	return v < math.MinInt || v > math.MaxInt
+}
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+/*----- Extension: Deep & Shallow Copies -----*/
+
+// copyKey identifies an already copied pointer, map or slice so shared and
+// cyclic references are copied once and stay shared in the result
+type copyKey struct {
+	ptr uintptr
+	typ reflect.Type
+	len int // slices with the same start but different lengths differ
+}
+
+// 1. DeepCopy - returns a copy of v that shares no memory with it
+// Structs (including unexported fields), arrays, slices, maps, pointers and
+// interfaces are copied recursively, and cycles are preserved rather than
+// followed forever. Channels, functions and unsafe pointers cannot be copied
+// and are shared; AliasReport lists them. *time.Location and reflect.Type
+// values are shared too, since time.Local and type descriptors are compared
+// by identity and a copy of them would no longer match.
+func DeepCopy[T any](v T) T {
+	src := reflect.ValueOf(&v).Elem()
+	dst := reflect.New(src.Type()).Elem()
+	deepCopyValue(dst, src, make(map[copyKey]reflect.Value))
+	return dst.Interface().(T)
+}
+
+// Types whose values are identities rather than data
+var (
+	locationType    = reflect.TypeFor[*time.Location]()
+	reflectTypeType = reflect.TypeFor[reflect.Type]()
+)
+
+// sharedByIdentity reports whether DeepCopy shares values of t as they are
+func sharedByIdentity(t reflect.Type) bool {
+	return t == locationType || t.Implements(reflectTypeType)
+}
+
+// deepCopyValue copies src into the settable dst of the same type
+func deepCopyValue(dst, src reflect.Value, seen map[copyKey]reflect.Value) {
+	if k := src.Kind(); (k == reflect.Pointer || k == reflect.Interface) && sharedByIdentity(src.Type()) {
+		dst.Set(src)
+		return
+	}
+
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			return
+		}
+		key := copyKey{ptr: src.Pointer(), typ: src.Type()}
+		if p, ok := seen[key]; ok {
+			dst.Set(p)
+			return
+		}
+		p := reflect.New(src.Type().Elem())
+		seen[key] = p
+		deepCopyValue(p.Elem(), src.Elem(), seen)
+		dst.Set(p)
+
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		elem := reflect.New(src.Elem().Type()).Elem()
+		deepCopyValue(elem, src.Elem(), seen)
+		dst.Set(elem)
+
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		key := copyKey{ptr: src.Pointer(), typ: src.Type(), len: src.Len()}
+		if s, ok := seen[key]; ok {
+			dst.Set(s)
+			return
+		}
+		s := reflect.MakeSlice(src.Type(), src.Len(), src.Cap())
+		seen[key] = s
+		for i := 0; i < src.Len(); i++ {
+			deepCopyValue(s.Index(i), src.Index(i), seen)
+		}
+		dst.Set(s)
+
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			deepCopyValue(dst.Index(i), src.Index(i), seen)
+		}
+
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		key := copyKey{ptr: src.Pointer(), typ: src.Type()}
+		if m, ok := seen[key]; ok {
+			dst.Set(m)
+			return
+		}
+		m := reflect.MakeMapWithSize(src.Type(), src.Len())
+		seen[key] = m
+		iter := src.MapRange()
+		for iter.Next() {
+			k := reflect.New(src.Type().Key()).Elem()
+			deepCopyValue(k, iter.Key(), seen)
+			v := reflect.New(src.Type().Elem()).Elem()
+			deepCopyValue(v, iter.Value(), seen)
+			m.SetMapIndex(k, v)
+		}
+		dst.Set(m)
+
+	case reflect.Struct:
+		// Unexported fields are read-only through reflect, so both sides
+		// are reached through addressable values and unsafe pointers
+		if !src.CanAddr() {
+			addressable := reflect.New(src.Type()).Elem()
+			addressable.Set(src)
+			src = addressable
+		}
+		for i := 0; i < src.NumField(); i++ {
+			deepCopyValue(writableField(dst, i), writableField(src, i), seen)
+		}
+
+	default:
+		// Basic kinds are values already; chans, funcs and unsafe pointers
+		// are shared
+		dst.Set(src)
+	}
+}
+
+// writableField returns field i of the addressable struct v with the
+// read-only flag that unexported fields carry removed
+func writableField(v reflect.Value, i int) reflect.Value {
+	f := v.Field(i)
+	if f.CanSet() {
+		return f
+	}
+	return reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem()
+}
+
+// Alias is memory that an original and a copy both point to
+type Alias struct {
+	Path string // e.g. "value.Items[2]" or `value.Tags["k"]`
+	Kind reflect.Kind
+}
+
+// 2. AliasReport - walks original and cp side by side and lists every
+// pointer, slice, map or channel they share
+// Shared memory is reported once, at the shallowest path; functions are not
+// compared because closures with the same code are indistinguishable.
+func AliasReport(original, cp any) []Alias {
+	var aliases []Alias
+	seen := make(map[[2]uintptr]bool)
+	reportAliases("value", reflect.ValueOf(original), reflect.ValueOf(cp), seen, &aliases)
+	return aliases
+}
+
+// reportAliases appends the aliases between a and b, which have the same type
+func reportAliases(path string, a, b reflect.Value, seen map[[2]uintptr]bool, aliases *[]Alias) {
+	if !a.IsValid() || !b.IsValid() || a.Type() != b.Type() {
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Chan:
+		if a.IsNil() || b.IsNil() {
+			return
+		}
+		if a.Pointer() == b.Pointer() {
+			*aliases = append(*aliases, Alias{Path: path, Kind: a.Kind()})
+			return
+		}
+		pair := [2]uintptr{a.Pointer(), b.Pointer()}
+		if seen[pair] {
+			return
+		}
+		seen[pair] = true
+
+		switch a.Kind() {
+		case reflect.Pointer:
+			reportAliases(path, a.Elem(), b.Elem(), seen, aliases)
+		case reflect.Map:
+			keys := a.MapKeys()
+			sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+			for _, k := range keys {
+				reportAliases(fmt.Sprintf("%s[%#v]", path, k), a.MapIndex(k), b.MapIndex(k), seen, aliases)
+			}
+		}
+
+	case reflect.Slice:
+		// Like ShareBackingArray: spare capacity counts, since append writes there
+		if rangesIntersect(a.Pointer(), a.Cap(), b.Pointer(), b.Cap(), a.Type().Elem().Size()) {
+			*aliases = append(*aliases, Alias{Path: path, Kind: reflect.Slice})
+			return
+		}
+		for i := 0; i < min(a.Len(), b.Len()); i++ {
+			reportAliases(fmt.Sprintf("%s[%d]", path, i), a.Index(i), b.Index(i), seen, aliases)
+		}
+
+	case reflect.Array:
+		for i := 0; i < a.Len(); i++ {
+			reportAliases(fmt.Sprintf("%s[%d]", path, i), a.Index(i), b.Index(i), seen, aliases)
+		}
+
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			reportAliases(path+"."+a.Type().Field(i).Name, a.Field(i), b.Field(i), seen, aliases)
+		}
+
+	case reflect.Interface:
+		reportAliases(path, a.Elem(), b.Elem(), seen, aliases)
+	}
+}
+
+// FormatAliasReport renders aliases one per line, or a note that there are none
+func FormatAliasReport(aliases []Alias) string {
+	if len(aliases) == 0 {
+		return "  no shared memory\n"
+	}
+	var b strings.Builder
+	for _, a := range aliases {
+		fmt.Fprintf(&b, "  %-24s %s is shared\n", a.Path, a.Kind)
+	}
+	return b.String()
+}
+
+// copyDemo is the nested value `analyzer copies` copies three ways
+type copyDemo struct {
+	Name   string
+	Scores []int
+	Tags   map[string]string
+	Matrix [][]int
+	Parent *copyDemo
+}
+
+// runCopies handles `analyzer copies`
+func runCopies(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("copies", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if flags.NArg() != 0 {
+		return fmt.Errorf("%w: unexpected argument %q", errUsage, flags.Arg(0))
+	}
+
+	original := copyDemo{
+		Name:   "child",
+		Scores: []int{90, 85},
+		Tags:   map[string]string{"team": "go"},
+		Matrix: [][]int{{1, 2}, {3, 4}},
+		Parent: &copyDemo{Name: "parent"},
+	}
+
+	// Assignment copies the struct, but not what its fields point to
+	shallow := original
+	fmt.Fprintln(stdout, "shallow copy (b := a):")
+	fmt.Fprint(stdout, FormatAliasReport(AliasReport(original, shallow)))
+
+	// copy() duplicates the outer slice headers, as Pipeline does, but the
+	// inner rows are still shared
+	rows := make([][]int, len(original.Matrix))
+	copy(rows, original.Matrix)
+	fmt.Fprintln(stdout, "copy() of a [][]int:")
+	fmt.Fprint(stdout, FormatAliasReport(AliasReport(original.Matrix, rows)))
+
+	deep := DeepCopy(original)
+	fmt.Fprintln(stdout, "DeepCopy(a):")
+	fmt.Fprint(stdout, FormatAliasReport(AliasReport(original, deep)))
+	return nil
+}
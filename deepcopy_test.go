@@ -0,0 +1,178 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+/*----- Extension: Deep & Shallow Copies -----*/
+
+type copyNode struct {
+	Value int
+	Next  *copyNode
+	Items []any
+	meta  map[string][]int // unexported fields are copied too
+}
+
+// 1. DeepCopy
+func TestDeepCopy(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+	}{
+		{name: "int", value: 42},
+		{name: "string", value: "text"},
+		{name: "nil slice", value: []int(nil)},
+		{name: "nested slices", value: [][]int{{1, 2}, {3}, nil}},
+		{name: "map of slices", value: map[string][]int{"a": {1}, "b": {2, 3}}},
+		{name: "array of pointers", value: [2]*int{Ptr(1), nil}},
+		{name: "struct with unexported map", value: copyNode{Value: 1, meta: map[string][]int{"k": {7}}}},
+		{name: "interfaces", value: []any{1, "two", []int{3}, map[int]int{4: 4}, nil}},
+		{name: "copy demo", value: copyDemo{Scores: []int{1}, Tags: map[string]string{"a": "b"}, Matrix: [][]int{{1}}, Parent: &copyDemo{Name: "p"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cp := DeepCopy(tt.value)
+			if !reflect.DeepEqual(cp, tt.value) {
+				t.Fatalf("DeepCopy() = %#v, want %#v", cp, tt.value)
+			}
+			if aliases := AliasReport(tt.value, cp); len(aliases) != 0 {
+				t.Errorf("DeepCopy() shares memory: %v", aliases)
+			}
+		})
+	}
+}
+
+func TestDeepCopyIsIndependent(t *testing.T) {
+	original := &copyNode{Value: 1, Items: []any{[]int{1, 2}}, meta: map[string][]int{"k": {1}}}
+	cp := DeepCopy(original)
+
+	cp.Value = 2
+	cp.Items[0].([]int)[0] = 100
+	cp.meta["k"][0] = 100
+	cp.meta["new"] = nil
+
+	if original.Value != 1 || original.Items[0].([]int)[0] != 1 || original.meta["k"][0] != 1 || len(original.meta) != 1 {
+		t.Errorf("changing the copy changed the original: %+v", original)
+	}
+}
+
+func TestDeepCopyCycles(t *testing.T) {
+	t.Run("pointer cycle", func(t *testing.T) {
+		a := &copyNode{Value: 1}
+		b := &copyNode{Value: 2, Next: a}
+		a.Next = b
+
+		cp := DeepCopy(a)
+		if cp == a || cp.Next == b {
+			t.Fatal("DeepCopy() reused original nodes")
+		}
+		if cp.Next.Next != cp || cp.Next.Value != 2 {
+			t.Errorf("DeepCopy() did not preserve the cycle: %p -> %p -> %p", cp, cp.Next, cp.Next.Next)
+		}
+	})
+
+	t.Run("slice containing itself", func(t *testing.T) {
+		s := make([]any, 1)
+		s[0] = s
+		cp := DeepCopy(s)
+		inner := cp[0].([]any)
+		if &inner[0] != &cp[0] || &cp[0] == &s[0] {
+			t.Error("DeepCopy() did not map the self-reference onto the copy")
+		}
+	})
+
+	t.Run("shared pointer stays shared", func(t *testing.T) {
+		shared := Ptr(5)
+		pair := [2]*int{shared, shared}
+		cp := DeepCopy(pair)
+		if cp[0] != cp[1] || cp[0] == shared {
+			t.Errorf("DeepCopy() = %p, %p; want one new pointer used twice", cp[0], cp[1])
+		}
+	})
+}
+
+func TestDeepCopySharesIdentities(t *testing.T) {
+	t.Run("time.Local", func(t *testing.T) {
+		original := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.Local)
+		cp := DeepCopy(original)
+		if cp.Location() != time.Local || !cp.Equal(original) {
+			t.Errorf("DeepCopy() = %v in %p, want %v in time.Local (%p)", cp, cp.Location(), original, time.Local)
+		}
+	})
+
+	t.Run("reflect.Type", func(t *testing.T) {
+		original := struct {
+			Type reflect.Type
+			Any  any
+		}{Type: reflect.TypeOf(0), Any: reflect.TypeOf("")}
+		cp := DeepCopy(original)
+		if cp.Type != reflect.TypeOf(0) || cp.Any != reflect.TypeOf("") {
+			t.Errorf("DeepCopy() = %v, %v; want the descriptors of int and string", cp.Type, cp.Any)
+		}
+	})
+}
+
+// 2. AliasReport
+func TestAliasReport(t *testing.T) {
+	ch := make(chan int)
+	original := copyDemo{
+		Scores: []int{1, 2, 3},
+		Tags:   map[string]string{"a": "b"},
+		Matrix: [][]int{{1}, {2}},
+		Parent: &copyDemo{Scores: []int{9}},
+	}
+
+	partial := DeepCopy(original)
+	partial.Matrix[1] = original.Matrix[1]
+	partial.Parent.Scores = original.Parent.Scores[:0]
+
+	tests := []struct {
+		name     string
+		original any
+		cp       any
+		want     []string
+	}{
+		{name: "assignment", original: original, cp: original, want: []string{"value.Scores slice", "value.Tags map", "value.Matrix slice", "value.Parent ptr"}},
+		{name: "selective sharing", original: original, cp: partial, want: []string{"value.Matrix[1] slice", "value.Parent.Scores slice"}},
+		{name: "resliced window shares spare capacity", original: original.Scores[:1], cp: original.Scores[2:], want: []string{"value slice"}},
+		{name: "channels", original: []chan int{ch}, cp: []chan int{ch}, want: []string{"value[0] chan"}},
+		{name: "different types", original: 1, cp: "1", want: nil},
+		{name: "nil values", original: nil, cp: nil, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, a := range AliasReport(tt.original, tt.cp) {
+				got = append(got, a.Path+" "+a.Kind.String())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("AliasReport() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunCopies(t *testing.T) {
+	var stdout strings.Builder
+	if err := runCopies(nil, &stdout); err != nil {
+		t.Fatalf("runCopies() error = %v", err)
+	}
+
+	sections := strings.Split(stdout.String(), "DeepCopy(a):\n")
+	if len(sections) != 2 || sections[1] != "  no shared memory\n" {
+		t.Errorf("runCopies() DeepCopy section = %q, want no shared memory", stdout.String())
+	}
+	if !strings.Contains(sections[0], "value.Parent") || !strings.Contains(sections[0], "value[1]") {
+		t.Errorf("runCopies() shallow sections = %q, want shared Parent and rows", sections[0])
+	}
+
+	if err := runCopies([]string{"extra"}, &stdout); !errors.Is(err, errUsage) {
+		t.Errorf("runCopies(extra) error = %v, want a usage error", err)
+	}
+}
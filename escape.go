@@ -0,0 +1,346 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+/*----- Extension: Escape Analysis Report -----*/
+
+// EscapeKind is what the compiler decided about one value or function
+type EscapeKind string
+
+const (
+	Escapes       EscapeKind = "escapes"         // allocated on the heap
+	DoesNotEscape EscapeKind = "does-not-escape" // stays on the stack
+	Leaks         EscapeKind = "leaks"           // a parameter flows out of its function
+	Inlined       EscapeKind = "inlined"         // inlinable function or inlined call site
+	NotInlined    EscapeKind = "not-inlined"
+)
+
+// EscapeRecord is one diagnostic from `go build -gcflags=-m=2`
+type EscapeRecord struct {
+	File     string     `json:"file"`
+	Line     int        `json:"line"`
+	Column   int        `json:"column"`
+	Function string     `json:"function,omitempty"`
+	Variable string     `json:"variable"`
+	Kind     EscapeKind `json:"kind"`
+	Reason   string     `json:"reason,omitempty"`
+}
+
+var (
+	// diagnosticLine matches "./main.go:12:9: message"
+	diagnosticLine = regexp.MustCompile(`^(.+?):(\d+):(\d+): (.*)$`)
+
+	// Headers open a block of indented "flow:" and "from" lines at -m=2
+	escapeHeader = regexp.MustCompile(`^(.+) escapes to heap in (.+):$`)
+	leakHeader   = regexp.MustCompile(`^parameter (\S+) leaks to .+ for (.+) with derefs=-?\d+:$`)
+	flowReason   = regexp.MustCompile(`^from .* \(([^)]+)\) at `)
+
+	movedToHeap   = regexp.MustCompile(`^moved to heap: (.+)$`)
+	escapesToHeap = regexp.MustCompile(`^(.+) escapes to heap$`)
+	doesNotEscape = regexp.MustCompile(`^(.+) does not escape$`)
+	leakingParam  = regexp.MustCompile(`^leaking param(?: content)?: (\S+)\s*(.*)$`)
+	canInline     = regexp.MustCompile(`^can inline (\S+) with cost (\d+)`)
+	cannotInline  = regexp.MustCompile(`^cannot inline (\S+): (.+)$`)
+	inliningCall  = regexp.MustCompile(`^inlining call to (.+)$`)
+)
+
+// escapeFlow is the explanation the compiler prints before a verdict
+// Several values can share a position, e.g. a make and the append that
+// grows it after inlining, so each flow remembers its expression.
+type escapeFlow struct {
+	expr     string
+	function string
+	reasons  []string
+}
+
+// 1. ParseEscapeOutput - turns compiler diagnostics into records sorted by
+// position; lines it does not recognise (devirtualizing, closure capture
+// details, package headers) are skipped
+func ParseEscapeOutput(r io.Reader) ([]EscapeRecord, error) {
+	flows := make(map[string][]*escapeFlow) // keyed by "file:line:col"
+	var current *escapeFlow                 // the header detail lines belong to
+	var currentPos string
+	seen := make(map[EscapeRecord]bool)
+	var records []EscapeRecord
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024) // inline bodies make long lines
+	for scanner.Scan() {
+		m := diagnosticLine.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+		pos, msg := m[1]+":"+m[2]+":"+m[3], m[4]
+
+		if detail, ok := strings.CutPrefix(msg, " "); ok {
+			if current != nil && currentPos == pos {
+				if reason := flowReason.FindStringSubmatch(strings.TrimSpace(detail)); reason != nil {
+					current.addReason(reason[1])
+				}
+			}
+			continue
+		}
+		if h := escapeHeader.FindStringSubmatch(msg); h != nil {
+			current, currentPos = addFlow(flows, pos, h[1], h[2]), pos
+			continue
+		}
+		if h := leakHeader.FindStringSubmatch(msg); h != nil {
+			current, currentPos = addFlow(flows, pos, h[1], h[2]), pos
+			continue
+		}
+		current = nil
+
+		record, ok := classifyDiagnostic(msg)
+		if !ok {
+			continue
+		}
+		record.File = filepath.Clean(m[1])
+		record.Line, _ = strconv.Atoi(m[2])
+		record.Column, _ = strconv.Atoi(m[3])
+		if flow := findFlow(flows[pos], record.Variable); flow != nil && (record.Kind == Escapes || record.Kind == Leaks) {
+			record.Function = flow.function
+			if len(flow.reasons) > 0 {
+				record.Reason = strings.Join(flow.reasons, ", ")
+			}
+		}
+
+		// Inlining decisions are printed once per use of the function
+		if !seen[record] {
+			seen[record] = true
+			records = append(records, record)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		a, b := records[i], records[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return records, nil
+}
+
+// addFlow returns the flow for expr at pos, creating it on first sight
+// A parameter gets one header per deref level; their reasons are merged.
+func addFlow(flows map[string][]*escapeFlow, pos, expr, function string) *escapeFlow {
+	for _, f := range flows[pos] {
+		if f.expr == expr && f.function == function {
+			return f
+		}
+	}
+	f := &escapeFlow{expr: expr, function: function}
+	flows[pos] = append(flows[pos], f)
+	return f
+}
+
+// findFlow picks the flow explaining the verdict about variable
+// Verdicts usually repeat the header's expression, but a call can be
+// shortened to its name: "append escapes to heap" follows
+// "append(result, num) escapes to heap in f:".
+func findFlow(candidates []*escapeFlow, variable string) *escapeFlow {
+	for _, f := range candidates {
+		if f.expr == variable {
+			return f
+		}
+	}
+	for _, f := range candidates {
+		if strings.HasPrefix(f.expr, variable+"(") {
+			return f
+		}
+	}
+	return nil
+}
+
+// addReason records why a value flowed somewhere, skipping compiler
+// bookkeeping ("spill") and repeats
+func (f *escapeFlow) addReason(reason string) {
+	if reason == "spill" {
+		return
+	}
+	for _, r := range f.reasons {
+		if r == reason {
+			return
+		}
+	}
+	f.reasons = append(f.reasons, reason)
+}
+
+// classifyDiagnostic turns a single verdict message into a record without
+// a position
+func classifyDiagnostic(msg string) (EscapeRecord, bool) {
+	if m := movedToHeap.FindStringSubmatch(msg); m != nil {
+		return EscapeRecord{Variable: m[1], Kind: Escapes}, true
+	}
+	if m := escapesToHeap.FindStringSubmatch(msg); m != nil {
+		return EscapeRecord{Variable: m[1], Kind: Escapes}, true
+	}
+	if m := doesNotEscape.FindStringSubmatch(msg); m != nil {
+		return EscapeRecord{Variable: m[1], Kind: DoesNotEscape}, true
+	}
+	if m := leakingParam.FindStringSubmatch(msg); m != nil {
+		return EscapeRecord{Variable: m[1], Kind: Leaks, Reason: m[2]}, true
+	}
+	if m := canInline.FindStringSubmatch(msg); m != nil {
+		return EscapeRecord{Function: m[1], Variable: m[1], Kind: Inlined, Reason: "cost " + m[2]}, true
+	}
+	if m := cannotInline.FindStringSubmatch(msg); m != nil {
+		return EscapeRecord{Function: m[1], Variable: m[1], Kind: NotInlined, Reason: m[2]}, true
+	}
+	if m := inliningCall.FindStringSubmatch(msg); m != nil {
+		return EscapeRecord{Variable: m[1], Kind: Inlined, Reason: "call inlined"}, true
+	}
+	return EscapeRecord{}, false
+}
+
+// 2. AnalyzeEscapes - compiles the package in dir with -gcflags=-m=2 and
+// parses what the compiler reports
+// Nothing is written to disk; the binary goes to the null device.
+func AnalyzeEscapes(dir string) ([]EscapeRecord, error) {
+	if info, err := os.Stat(dir); err != nil {
+		return nil, err
+	} else if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	var output bytes.Buffer
+	cmd := exec.Command("go", "build", "-gcflags=-m=2", "-o", os.DevNull, ".")
+	cmd.Dir = dir
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("go build failed:\n%s", strings.TrimSpace(output.String()))
+		}
+		return nil, err
+	}
+
+	records, err := ParseEscapeOutput(&output)
+	if err != nil {
+		return nil, err
+	}
+	goroot, err := exec.Command("go", "env", "GOROOT").Output()
+	if err != nil {
+		return nil, err
+	}
+	return withoutGOROOT(records, strings.TrimSpace(string(goroot))), nil
+}
+
+// withoutGOROOT drops records for standard library files, which the
+// compiler reports when the package instantiates a generic type from them
+func withoutGOROOT(records []EscapeRecord, goroot string) []EscapeRecord {
+	prefix := filepath.Clean(goroot) + string(filepath.Separator)
+	kept := records[:0]
+	for _, r := range records {
+		if !strings.HasPrefix(r.File, prefix) {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
+
+// escapeFormats maps each --format value to its writer
+var escapeFormats = map[string]func(w io.Writer, records []EscapeRecord) error{
+	"table": writeEscapeTable,
+	"json":  writeEscapeJSON,
+}
+
+// runEscape handles `analyzer escape [--format table|json] [--kind k,...] <package-dir>`
+func runEscape(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("escape", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	format := flags.String("format", "table", "output format: table or json")
+	kinds := flags.String("kind", "", "comma-separated kinds to keep, e.g. escapes,leaks")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("%w: expected one package directory", errUsage)
+	}
+
+	write, ok := escapeFormats[*format]
+	if !ok {
+		return fmt.Errorf("%w: unknown format %q (want table or json)", errUsage, *format)
+	}
+	keep, err := parseEscapeKinds(*kinds)
+	if err != nil {
+		return err
+	}
+
+	records, err := AnalyzeEscapes(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	filtered := records[:0]
+	for _, r := range records {
+		if keep == nil || keep[r.Kind] {
+			filtered = append(filtered, r)
+		}
+	}
+	return write(stdout, filtered)
+}
+
+// parseEscapeKinds parses the --kind list; an empty list keeps everything
+func parseEscapeKinds(list string) (map[EscapeKind]bool, error) {
+	if list == "" {
+		return nil, nil
+	}
+
+	known := []EscapeKind{Escapes, DoesNotEscape, Leaks, Inlined, NotInlined}
+	keep := make(map[EscapeKind]bool)
+	for _, name := range strings.Split(list, ",") {
+		kind := EscapeKind(strings.TrimSpace(name))
+		valid := false
+		for _, k := range known {
+			valid = valid || k == kind
+		}
+		if !valid {
+			return nil, fmt.Errorf("%w: unknown kind %q (want %v)", errUsage, kind, known)
+		}
+		keep[kind] = true
+	}
+	return keep, nil
+}
+
+// writeEscapeTable renders the records as aligned columns
+func writeEscapeTable(w io.Writer, records []EscapeRecord) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "POSITION\tKIND\tFUNCTION\tVARIABLE\tREASON")
+	for _, r := range records {
+		fmt.Fprintf(tw, "%s:%d:%d\t%s\t%s\t%s\t%s\n", r.File, r.Line, r.Column, r.Kind, r.Function, r.Variable, r.Reason)
+	}
+	return tw.Flush()
+}
+
+// writeEscapeJSON renders the records as an indented JSON array
+func writeEscapeJSON(w io.Writer, records []EscapeRecord) error {
+	if records == nil {
+		records = []EscapeRecord{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(records)
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+/*----- Extension: Escape Analysis Report -----*/
+
+// 1. ParseEscapeOutput
+func TestParseEscapeOutputFixture(t *testing.T) {
+	// demo.m2.txt is `go build -gcflags=-m=2` run in testdata/escape/demo
+	f, err := os.Open("testdata/escape/demo.m2.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	records, err := ParseEscapeOutput(f)
+	if err != nil {
+		t.Fatalf("ParseEscapeOutput() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		want EscapeRecord
+	}{
+		{
+			name: "returned address moves to the heap",
+			want: EscapeRecord{File: "demo.go", Line: 11, Column: 2, Function: "CreateOnHeap", Variable: "x", Kind: Escapes, Reason: "address-of, return"},
+		},
+		{
+			name: "closure capture moves to the heap",
+			want: EscapeRecord{File: "demo.go", Line: 16, Column: 2, Function: "MakeCounter", Variable: "count", Kind: Escapes, Reason: "captured by a closure, reference"},
+		},
+		{
+			name: "returned parameter leaks",
+			want: EscapeRecord{File: "demo.go", Line: 23, Column: 15, Function: "Identity", Variable: "p", Kind: Leaks, Reason: "return"},
+		},
+		{
+			name: "summary line keeps its own wording",
+			want: EscapeRecord{File: "demo.go", Line: 37, Column: 17, Function: "Grow", Variable: "append", Kind: Escapes, Reason: "assign, return"},
+		},
+		{
+			name: "read-only slice parameter stays put",
+			want: EscapeRecord{File: "demo.go", Line: 27, Column: 10, Variable: "nums", Kind: DoesNotEscape},
+		},
+		{
+			name: "inlinable function",
+			want: EscapeRecord{File: "demo.go", Line: 5, Column: 6, Function: "CreateOnStack", Variable: "CreateOnStack", Kind: Inlined, Reason: "cost 7"},
+		},
+		{
+			name: "inlined call site",
+			want: EscapeRecord{File: "demo.go", Line: 44, Column: 19, Variable: "CreateOnHeap", Kind: Inlined, Reason: "call inlined"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, r := range records {
+				if r == tt.want {
+					return
+				}
+			}
+			t.Errorf("ParseEscapeOutput() has no record %+v", tt.want)
+		})
+	}
+
+	// CreateOnStack's x never reaches the heap, so the compiler says nothing
+	for _, r := range records {
+		if r.Line == 6 {
+			t.Errorf("ParseEscapeOutput() reported %+v for CreateOnStack's local", r)
+		}
+	}
+	for i := 1; i < len(records); i++ {
+		if records[i].Line < records[i-1].Line {
+			t.Fatalf("ParseEscapeOutput() records are not sorted by position")
+		}
+	}
+}
+
+func TestParseEscapeOutput(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   []EscapeRecord
+	}{
+		{
+			name:   "package header and unrelated lines are skipped",
+			output: "# example.com/p\n./a.go:3:6: devirtualizing err.Error to *errors.errorString\nnot a diagnostic\n",
+			want:   nil,
+		},
+		{
+			name:   "cannot inline",
+			output: "./a.go:3:6: cannot inline Big: function too complex: cost 89 exceeds budget 80\n",
+			want:   []EscapeRecord{{File: "a.go", Line: 3, Column: 6, Function: "Big", Variable: "Big", Kind: NotInlined, Reason: "function too complex: cost 89 exceeds budget 80"}},
+		},
+		{
+			name:   "leaking param content without a flow",
+			output: "./a.go:4:10: leaking param content: nums\n",
+			want:   []EscapeRecord{{File: "a.go", Line: 4, Column: 10, Variable: "nums", Kind: Leaks}},
+		},
+		{
+			name:   "leaking param keeps its destination",
+			output: "./a.go:4:10: leaking param: p to result ~r0 level=0\n",
+			want:   []EscapeRecord{{File: "a.go", Line: 4, Column: 10, Variable: "p", Kind: Leaks, Reason: "to result ~r0 level=0"}},
+		},
+		{
+			name:   "repeated inlining decisions are reported once",
+			output: "./a.go:5:9: can inline F.func1 with cost 5 as: func() {}\n./a.go:5:9: can inline F.func1 with cost 5 as: func() {}\n",
+			want:   []EscapeRecord{{File: "a.go", Line: 5, Column: 9, Function: "F.func1", Variable: "F.func1", Kind: Inlined, Reason: "cost 5"}},
+		},
+		{
+			name: "flows at one position stay with their own verdicts",
+			output: "./a.go:7:9: make([]int, 0, n) escapes to heap in Grow:\n" +
+				"./a.go:7:9:   flow: result ← &{storage for make([]int, 0, n)}:\n" +
+				"./a.go:7:9:     from result := make([]int, 0, n) (assign) at ./a.go:7:9\n" +
+				"./a.go:7:9: append(result, x) escapes to heap in Grow.func1:\n" +
+				"./a.go:7:9:   flow: {heap} ← result:\n" +
+				"./a.go:7:9:     from Keep(result) (call parameter) at ./a.go:7:3\n" +
+				"./a.go:7:9: make([]int, 0, n) escapes to heap\n" +
+				"./a.go:7:9: append escapes to heap\n",
+			want: []EscapeRecord{
+				{File: "a.go", Line: 7, Column: 9, Function: "Grow", Variable: "make([]int, 0, n)", Kind: Escapes, Reason: "assign"},
+				{File: "a.go", Line: 7, Column: 9, Function: "Grow.func1", Variable: "append", Kind: Escapes, Reason: "call parameter"},
+			},
+		},
+		{
+			name:   "escape without -m=2 detail",
+			output: "pkg/b.go:8:2: moved to heap: buf\n",
+			want:   []EscapeRecord{{File: "pkg/b.go", Line: 8, Column: 2, Variable: "buf", Kind: Escapes}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseEscapeOutput(strings.NewReader(tt.output))
+			if err != nil {
+				t.Fatalf("ParseEscapeOutput() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseEscapeOutput() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("record %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+// 2. AnalyzeEscapes via the escape command
+func TestRunEscape(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	t.Run("json output filtered by kind", func(t *testing.T) {
+		var stdout strings.Builder
+		err := runEscape([]string{"--format", "json", "--kind", "escapes", "testdata/escape/demo"}, &stdout)
+		if err != nil {
+			t.Fatalf("runEscape() error = %v", err)
+		}
+
+		var records []EscapeRecord
+		if err := json.Unmarshal([]byte(stdout.String()), &records); err != nil {
+			t.Fatalf("output is not JSON: %v\n%s", err, stdout.String())
+		}
+		found := false
+		for _, r := range records {
+			if r.Kind != Escapes {
+				t.Errorf("record %+v was not filtered out", r)
+			}
+			found = found || (r.Function == "CreateOnHeap" && r.Variable == "x")
+		}
+		if !found {
+			t.Errorf("runEscape() = %+v, want CreateOnHeap's x to escape", records)
+		}
+	})
+
+	t.Run("standard library files are dropped", func(t *testing.T) {
+		var stdout strings.Builder
+		if err := runEscape([]string{"--format", "json", "testdata/escape/demo"}, &stdout); err != nil {
+			t.Fatalf("runEscape() error = %v", err)
+		}
+
+		var records []EscapeRecord
+		if err := json.Unmarshal([]byte(stdout.String()), &records); err != nil {
+			t.Fatalf("output is not JSON: %v", err)
+		}
+		for _, r := range records {
+			if filepath.IsAbs(r.File) {
+				t.Errorf("record %+v is outside the analyzed package", r)
+			}
+		}
+	})
+
+	t.Run("table output", func(t *testing.T) {
+		var stdout strings.Builder
+		if err := runEscape([]string{"testdata/escape/demo"}, &stdout); err != nil {
+			t.Fatalf("runEscape() error = %v", err)
+		}
+		if !strings.HasPrefix(stdout.String(), "POSITION") || !strings.Contains(stdout.String(), "demo.go:11:2") {
+			t.Errorf("runEscape() table = %q", stdout.String())
+		}
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		err := runEscape([]string{"testdata/escape/missing"}, io.Discard)
+		if err == nil || errors.Is(err, errUsage) {
+			t.Errorf("runEscape() error = %v, want a non-usage error", err)
+		}
+	})
+
+	usageTests := [][]string{
+		nil,
+		{"--format", "xml", "testdata/escape/demo"},
+		{"--kind", "stack", "testdata/escape/demo"},
+		{"a", "b"},
+	}
+	for _, args := range usageTests {
+		if err := runEscape(args, io.Discard); !errors.Is(err, errUsage) {
+			t.Errorf("runEscape(%q) error = %v, want a usage error", args, err)
+		}
+	}
+}
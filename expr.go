@@ -0,0 +1,390 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+/*----- Extension: Expression Language (Tokenizer, Parser & AST) -----*/
+
+// The expression language, from lowest to highest precedence:
+//
+//	statement := IDENT ( "," IDENT )* "=" pipe | pipe
+//	pipe     := compare ( "|" compare )*         a | f(b) means f(b, a)
+//	compare  := range ( ("<"|"<="|">"|">="|"=="|"!=") range )?
+//	range    := additive ( ".." additive )?      inclusive list a..b
+//	additive := term ( ("+"|"-") term )*
+//	term     := unary ( ("*"|"/"|"%") unary )*
+//	unary    := "-" unary | primary
+//	primary  := INT | IDENT | IDENT "(" args ")" | "(" pipe ")"
+//	          | "[" args "]" | operator           operator as a function, e.g. reduce(+)
+
+// tokenKind classifies a token
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokInt
+	tokIdent
+	tokOp
+)
+
+// token is a lexical unit with its byte offset in the source
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// String describes a token for error messages
+func (t token) String() string {
+	if t.kind == tokEOF {
+		return "end of input"
+	}
+	return strconv.Quote(t.text)
+}
+
+// operators lists every operator, two-character ones first so they win
+var operators = []string{"..", "<=", ">=", "==", "!=", "+", "-", "*", "/", "%", "(", ")", "[", "]", ",", "|", "<", ">", "="}
+
+// tokenize splits src into tokens
+func tokenize(src string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(src); {
+		r := rune(src[i])
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(src) && unicode.IsDigit(rune(src[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokInt, text: src[start:i], pos: start})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(src) && (unicode.IsLetter(rune(src[i])) || unicode.IsDigit(rune(src[i])) || src[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: src[start:i], pos: start})
+		default:
+			op := ""
+			for _, candidate := range operators {
+				if strings.HasPrefix(src[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected character %q at column %d", r, i+1)
+			}
+			tokens = append(tokens, token{kind: tokOp, text: op, pos: i})
+			i += len(op)
+		}
+	}
+	return append(tokens, token{kind: tokEOF, pos: len(src)}), nil
+}
+
+// node is an AST node that can be evaluated in an environment
+type node interface {
+	eval(env *evalEnv) (value, error)
+}
+
+// AST node types
+type (
+	intLit    struct{ val int }
+	identNode struct{ name string }
+	opRefNode struct{ op string }
+	listNode  struct{ elems []node }
+	rangeNode struct{ lo, hi node }
+	unaryNode struct {
+		op string
+		x  node
+	}
+	binaryNode struct {
+		op   string
+		l, r node
+	}
+	callNode struct {
+		name string
+		args []node
+	}
+	assignNode struct {
+		names []string
+		x     node
+	}
+)
+
+// parser is a recursive-descent parser over a token slice
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// parseExpr parses a complete expression
+func parseExpr(src string) (node, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	n, err := p.parseStatement()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, p.errorf(tok, "unexpected %v", tok)
+	}
+	return n, nil
+}
+
+// peek returns the current token without consuming it
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+// next consumes and returns the current token
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// accept consumes the current token if it is one of the given operators
+func (p *parser) accept(ops ...string) (string, bool) {
+	tok := p.peek()
+	if tok.kind != tokOp {
+		return "", false
+	}
+	for _, op := range ops {
+		if tok.text == op {
+			p.pos++
+			return op, true
+		}
+	}
+	return "", false
+}
+
+// expect consumes the given operator or fails
+func (p *parser) expect(op string) error {
+	if _, ok := p.accept(op); !ok {
+		tok := p.peek()
+		return p.errorf(tok, "expected %q, found %v", op, tok)
+	}
+	return nil
+}
+
+// errorf reports a parse error at the column of tok
+func (p *parser) errorf(tok token, format string, args ...any) error {
+	return fmt.Errorf("%s at column %d", fmt.Sprintf(format, args...), tok.pos+1)
+}
+
+// parseStatement parses an optional assignment such as `c = counter(0)` or
+// `add, sub, get = accumulator(50)`
+func (p *parser) parseStatement() (node, error) {
+	var names []string
+	for i := p.pos; p.tokens[i].kind == tokIdent; i += 2 {
+		names = append(names, p.tokens[i].text)
+		sep := p.tokens[i+1]
+		if sep.kind != tokOp || (sep.text != "," && sep.text != "=") {
+			break
+		}
+		if sep.text == "=" {
+			p.pos = i + 2
+			x, err := p.parsePipe()
+			if err != nil {
+				return nil, err
+			}
+			return &assignNode{names: names, x: x}, nil
+		}
+	}
+	return p.parsePipe()
+}
+
+func (p *parser) parsePipe() (node, error) {
+	left, err := p.parseCompare()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if _, ok := p.accept("|"); !ok {
+			return left, nil
+		}
+		tok := p.peek()
+		right, err := p.parseCompare()
+		if err != nil {
+			return nil, err
+		}
+
+		// The piped value becomes the last argument of the call
+		switch r := right.(type) {
+		case *callNode:
+			left = &callNode{name: r.name, args: append(r.args, left)}
+		case *identNode:
+			left = &callNode{name: r.name, args: []node{left}}
+		default:
+			return nil, p.errorf(tok, "right side of | must be a function call")
+		}
+	}
+}
+
+func (p *parser) parseCompare() (node, error) {
+	left, err := p.parseRange()
+	if err != nil {
+		return nil, err
+	}
+	if op, ok := p.accept("<", "<=", ">", ">=", "==", "!="); ok {
+		right, err := p.parseRange()
+		if err != nil {
+			return nil, err
+		}
+		return &binaryNode{op: op, l: left, r: right}, nil
+	}
+	return left, nil
+}
+
+func (p *parser) parseRange() (node, error) {
+	lo, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.accept(".."); ok {
+		hi, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		return &rangeNode{lo: lo, hi: hi}, nil
+	}
+	return lo, nil
+}
+
+func (p *parser) parseAdditive() (node, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept("+", "-")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op, l: left, r: right}
+	}
+}
+
+func (p *parser) parseTerm() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept("*", "/", "%")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op, l: left, r: right}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.isOperatorRef() {
+		return p.parsePrimary()
+	}
+	if _, ok := p.accept("-"); ok {
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{op: "-", x: x}, nil
+	}
+	return p.parsePrimary()
+}
+
+// isOperatorRef reports whether the current token is an arithmetic operator
+// used as a function value, as in reduce(+) or reduce(*, 1, xs)
+func (p *parser) isOperatorRef() bool {
+	tok, after := p.peek(), p.tokens[min(p.pos+1, len(p.tokens)-1)]
+	if tok.kind != tokOp || !strings.Contains("+-*/%", tok.text) {
+		return false
+	}
+	return after.kind == tokOp && (after.text == "," || after.text == ")")
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	if p.isOperatorRef() {
+		return &opRefNode{op: p.next().text}, nil
+	}
+
+	tok := p.next()
+	switch tok.kind {
+	case tokInt:
+		n, err := strconv.Atoi(tok.text)
+		if err != nil {
+			return nil, p.errorf(tok, "integer %s out of range", tok.text)
+		}
+		return &intLit{val: n}, nil
+
+	case tokIdent:
+		if _, ok := p.accept("("); !ok {
+			return &identNode{name: tok.text}, nil
+		}
+		args, err := p.parseArgs(")")
+		if err != nil {
+			return nil, err
+		}
+		return &callNode{name: tok.text, args: args}, nil
+
+	case tokOp:
+		switch tok.text {
+		case "(":
+			n, err := p.parsePipe()
+			if err != nil {
+				return nil, err
+			}
+			return n, p.expect(")")
+		case "[":
+			elems, err := p.parseArgs("]")
+			if err != nil {
+				return nil, err
+			}
+			return &listNode{elems: elems}, nil
+		}
+	}
+	return nil, p.errorf(tok, "unexpected %v", tok)
+}
+
+// parseArgs parses a comma-separated list up to and including the closer
+func (p *parser) parseArgs(closer string) ([]node, error) {
+	args := []node{}
+	if _, ok := p.accept(closer); ok {
+		return args, nil
+	}
+	for {
+		arg, err := p.parsePipe()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+
+		if _, ok := p.accept(closer); ok {
+			return args, nil
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,671 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+/*----- Extension: Expression Language (Evaluator) -----*/
+
+// value is the result of evaluating a node: int, bool, []int, *funcValue,
+// tuple, or nil for calls that return nothing
+type value any
+
+// tuple holds several results returned together, like Go's multiple returns
+type tuple []value
+
+// funcValue is a callable value such as a builtin, lambda or composition
+// cost, when set, estimates the extra steps one call takes, e.g. the trial
+// divisions of isprime.
+type funcValue struct {
+	name string
+	call func(args []value) (value, error)
+	cost func(args []value) int
+}
+
+// ErrEvalLimit is returned when an expression needs more steps than
+// EvalExprContext allows
+var ErrEvalLimit = errors.New("expression exceeds the evaluation step limit")
+
+// evalBudget counts the steps an evaluation may still take and the context
+// that can cancel it; it is shared by every environment of one evaluation
+type evalBudget struct {
+	ctx        context.Context
+	steps      int
+	sinceCheck int
+}
+
+// budgetCheckInterval is how many steps pass between ctx.Err() checks
+const budgetCheckInterval = 1024
+
+// evalEnv binds variable names to values, falling back to its parent
+// A nil budget means evaluation is unbounded, as in the CLI and REPL.
+type evalEnv struct {
+	vars   map[string]value
+	parent *evalEnv
+	budget *evalBudget
+}
+
+// newEvalEnv creates an environment nested inside parent (which may be nil)
+func newEvalEnv(parent *evalEnv) *evalEnv {
+	env := &evalEnv{vars: map[string]value{}, parent: parent}
+	if parent != nil {
+		env.budget = parent.budget
+	}
+	return env
+}
+
+// spend charges n steps to the budget, failing once it runs out or the
+// evaluation's context is done
+func (e *evalEnv) spend(n int) error {
+	b := e.budget
+	if b == nil {
+		return nil
+	}
+
+	b.steps -= n
+	if b.steps < 0 {
+		return ErrEvalLimit
+	}
+	if b.sinceCheck += n; b.sinceCheck >= budgetCheckInterval {
+		b.sinceCheck = 0
+		return b.ctx.Err()
+	}
+	return nil
+}
+
+// call invokes f after charging one step plus f's own cost
+func (e *evalEnv) call(f *funcValue, args []value) (value, error) {
+	steps := 1
+	if f.cost != nil {
+		steps += f.cost(args)
+	}
+	if err := e.spend(steps); err != nil {
+		return nil, err
+	}
+	return f.call(args)
+}
+
+// lookup finds a variable in this environment or any parent
+func (e *evalEnv) lookup(name string) (value, bool) {
+	for env := e; env != nil; env = env.parent {
+		if v, ok := env.vars[name]; ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// EvalExpr parses and evaluates an expression such as
+// "map(x*x, filter(isprime, 1..100)) | reduce(+)"
+func EvalExpr(src string) (any, error) {
+	n, err := parseExpr(src)
+	if err != nil {
+		return nil, err
+	}
+	return n.eval(newEvalEnv(nil))
+}
+
+// EvalExprContext is EvalExpr for untrusted input: evaluation stops with
+// ErrEvalLimit after maxSteps steps, or with ctx.Err() once ctx is done
+// A step is roughly one operator, call, list element or trial division.
+func EvalExprContext(ctx context.Context, src string, maxSteps int) (any, error) {
+	n, err := parseExpr(src)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	env := newEvalEnv(nil)
+	env.budget = &evalBudget{ctx: ctx, steps: maxSteps}
+	return n.eval(env)
+}
+
+// FormatValue renders an evaluated value the way the demo prints results
+func FormatValue(v any) string {
+	switch v := v.(type) {
+	case *funcValue:
+		return "<function " + v.name + ">"
+	case tuple:
+		parts := make([]string, len(v))
+		for i, elem := range v {
+			parts[i] = FormatValue(elem)
+		}
+		return "(" + strings.Join(parts, ", ") + ")"
+	}
+	return fmt.Sprint(v)
+}
+
+// builtins are functions that take already-evaluated arguments
+var builtins = map[string]*funcValue{
+	// Checked like the CLI: an overflowing result is an error, never a
+	// silently wrapped value, and huge inputs fail fast instead of looping
+	"factorial": intFunc("factorial", 1, func(a []int) (value, error) { return checkedFactorial(a[0]) }),
+	"power":     intFunc("power", 2, func(a []int) (value, error) { return checkedPower(a[0], a[1]) }),
+	"isprime": withCost(intFunc("isprime", 1, func(a []int) (value, error) {
+		// IsPrime rejects n < 2, but as a predicate "not prime" is the useful
+		// answer so filter(isprime, 1..100) works
+		if a[0] < 2 {
+			return false, nil
+		}
+		return IsPrime(a[0])
+	}), isPrimeCost),
+
+	// Closure factories: each call captures fresh state, so two counters
+	// count independently just like in the demo
+	"counter": intFunc("counter", 1, func(a []int) (value, error) {
+		next := MakeCounter(a[0])
+		return intFunc("counter", 0, func([]int) (value, error) { return next(), nil }), nil
+	}),
+	"multiplier": intFunc("multiplier", 1, func(a []int) (value, error) {
+		multiply := MakeMultiplier(a[0])
+		return intFunc("multiplier", 1, func(b []int) (value, error) { return multiply(b[0]), nil }), nil
+	}),
+	"accumulator": intFunc("accumulator", 1, func(a []int) (value, error) {
+		add, subtract, get := MakeAccumulator(a[0])
+		return tuple{
+			intFunc("add", 1, func(b []int) (value, error) { add(b[0]); return nil, nil }),
+			intFunc("subtract", 1, func(b []int) (value, error) { subtract(b[0]); return nil, nil }),
+			intFunc("get", 0, func([]int) (value, error) { return get(), nil }),
+		}, nil
+	}),
+}
+
+// specialForms receive their arguments unevaluated so that an argument
+// mentioning x (or acc) can become a lambda, as in map(x*x, xs)
+var specialForms map[string]func(env *evalEnv, args []node) (value, error)
+
+func init() {
+	specialForms = map[string]func(env *evalEnv, args []node) (value, error){
+		"map":     evalMap,
+		"filter":  evalFilter,
+		"reduce":  evalReduce,
+		"compose": evalCompose,
+	}
+}
+
+// withCost attaches a step estimate to a builtin
+func withCost(f *funcValue, cost func(args []value) int) *funcValue {
+	f.cost = cost
+	return f
+}
+
+// isPrimeCost is the number of odd trial divisors IsPrime tries for n
+func isPrimeCost(args []value) int {
+	if len(args) != 1 {
+		return 0
+	}
+	n, ok := args[0].(int)
+	if !ok || n < 2 {
+		return 0
+	}
+	return int(math.Sqrt(float64(n))) / 2
+}
+
+// intFunc wraps a Go function over ints as a funcValue with fixed arity
+func intFunc(name string, arity int, fn func(args []int) (value, error)) *funcValue {
+	return &funcValue{name: name, call: func(args []value) (value, error) {
+		if len(args) != arity {
+			return nil, fmt.Errorf("%s expects %d argument(s), got %d", name, arity, len(args))
+		}
+		ints := make([]int, arity)
+		for i, arg := range args {
+			n, ok := arg.(int)
+			if !ok {
+				return nil, fmt.Errorf("%s expects integer arguments, got %s", name, FormatValue(arg))
+			}
+			ints[i] = n
+		}
+		return fn(ints)
+	}}
+}
+
+func (n *intLit) eval(env *evalEnv) (value, error) {
+	return n.val, nil
+}
+
+func (n *identNode) eval(env *evalEnv) (value, error) {
+	if v, ok := env.lookup(n.name); ok {
+		return v, nil
+	}
+	if f, ok := builtins[n.name]; ok {
+		return f, nil
+	}
+	if _, ok := specialForms[n.name]; ok {
+		return nil, fmt.Errorf("%s must be called, it cannot be used as a value", n.name)
+	}
+	return nil, fmt.Errorf("undefined name %q", n.name)
+}
+
+func (n *opRefNode) eval(env *evalEnv) (value, error) {
+	return &funcValue{name: n.op, call: func(args []value) (value, error) {
+		if len(args) != 2 {
+			return nil, fmt.Errorf("%s expects 2 arguments, got %d", n.op, len(args))
+		}
+		return applyOperator(n.op, args[0], args[1])
+	}}, nil
+}
+
+func (n *listNode) eval(env *evalEnv) (value, error) {
+	if err := env.spend(len(n.elems)); err != nil {
+		return nil, err
+	}
+	list := make([]int, len(n.elems))
+	for i, elem := range n.elems {
+		v, err := evalInt(env, elem, "list element")
+		if err != nil {
+			return nil, err
+		}
+		list[i] = v
+	}
+	return list, nil
+}
+
+func (n *rangeNode) eval(env *evalEnv) (value, error) {
+	lo, err := evalInt(env, n.lo, "range start")
+	if err != nil {
+		return nil, err
+	}
+	hi, err := evalInt(env, n.hi, "range end")
+	if err != nil {
+		return nil, err
+	}
+	if hi < lo {
+		return []int{}, nil
+	}
+	// hi-lo can overflow int for ranges spanning zero, but never uint64
+	if uint64(hi)-uint64(lo) >= maxRangeLen {
+		return nil, fmt.Errorf("range %d..%d is longer than %d elements", lo, hi, maxRangeLen)
+	}
+	if err := env.spend(hi - lo + 1); err != nil {
+		return nil, err
+	}
+
+	// Stop at hi before incrementing, so hi == math.MaxInt cannot wrap
+	list := make([]int, 0, hi-lo+1)
+	for i := lo; ; i++ {
+		list = append(list, i)
+		if i == hi {
+			break
+		}
+	}
+	return list, nil
+}
+
+// maxRangeLen keeps a typo like 1..1000000000 from exhausting memory
+const maxRangeLen = 10_000_000
+
+func (n *unaryNode) eval(env *evalEnv) (value, error) {
+	if err := env.spend(1); err != nil {
+		return nil, err
+	}
+	x, err := evalInt(env, n.x, "operand of -")
+	if err != nil {
+		return nil, err
+	}
+	return -x, nil
+}
+
+func (n *binaryNode) eval(env *evalEnv) (value, error) {
+	if err := env.spend(1); err != nil {
+		return nil, err
+	}
+	l, err := n.l.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	r, err := n.r.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	return applyOperator(n.op, l, r)
+}
+
+func (n *callNode) eval(env *evalEnv) (value, error) {
+	if err := env.spend(1); err != nil {
+		return nil, err
+	}
+	if _, shadowed := env.lookup(n.name); !shadowed {
+		if form, ok := specialForms[n.name]; ok {
+			return form(env, n.args)
+		}
+	}
+
+	callee, err := (&identNode{name: n.name}).eval(env)
+	if err != nil {
+		return nil, err
+	}
+	f, ok := callee.(*funcValue)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a function", n.name)
+	}
+
+	args := make([]value, len(n.args))
+	for i, arg := range n.args {
+		if args[i], err = arg.eval(env); err != nil {
+			return nil, err
+		}
+	}
+	return env.call(f, args)
+}
+
+// eval binds the result in env and evaluates to nil, so nothing is printed
+func (n *assignNode) eval(env *evalEnv) (value, error) {
+	v, err := n.x.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(n.names) == 1 {
+		if _, ok := v.(tuple); ok {
+			return nil, fmt.Errorf("cannot assign %s to a single name", FormatValue(v))
+		}
+		env.vars[n.names[0]] = v
+		return nil, nil
+	}
+
+	values, ok := v.(tuple)
+	if !ok || len(values) != len(n.names) {
+		return nil, fmt.Errorf("cannot assign %s to %d names", FormatValue(v), len(n.names))
+	}
+	for i, name := range n.names {
+		env.vars[name] = values[i]
+	}
+	return nil, nil
+}
+
+// applyOperator evaluates a binary arithmetic or comparison operator
+func applyOperator(op string, lv, rv value) (value, error) {
+	l, lok := lv.(int)
+	r, rok := rv.(int)
+	if !lok || !rok {
+		return nil, fmt.Errorf("operator %s expects integers, got %s and %s", op, FormatValue(lv), FormatValue(rv))
+	}
+
+	switch op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "/", "%":
+		if r == 0 {
+			return nil, errors.New("division by zero")
+		}
+		if op == "/" {
+			return l / r, nil
+		}
+		return l % r, nil
+	case "<":
+		return l < r, nil
+	case "<=":
+		return l <= r, nil
+	case ">":
+		return l > r, nil
+	case ">=":
+		return l >= r, nil
+	case "==":
+		return l == r, nil
+	case "!=":
+		return l != r, nil
+	}
+	return nil, fmt.Errorf("unknown operator %s", op)
+}
+
+// evalInt evaluates n and requires an integer result
+func evalInt(env *evalEnv, n node, what string) (int, error) {
+	v, err := n.eval(env)
+	if err != nil {
+		return 0, err
+	}
+	i, ok := v.(int)
+	if !ok {
+		return 0, fmt.Errorf("%s must be an integer, got %s", what, FormatValue(v))
+	}
+	return i, nil
+}
+
+// evalList evaluates n and requires a list result
+func evalList(env *evalEnv, n node, what string) ([]int, error) {
+	v, err := n.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	list, ok := v.([]int)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a list, got %s", what, FormatValue(v))
+	}
+	return list, nil
+}
+
+// evalFunc turns a function-position argument into a funcValue
+// An argument that mentions one of params becomes a lambda over them;
+// anything else must evaluate to a function, e.g. isprime or compose(...).
+func evalFunc(env *evalEnv, n node, params ...string) (*funcValue, error) {
+	if mentions(n, params...) {
+		return &funcValue{name: "lambda", call: func(args []value) (value, error) {
+			if len(args) != len(params) {
+				return nil, fmt.Errorf("lambda expects %d argument(s), got %d", len(params), len(args))
+			}
+			scope := newEvalEnv(env)
+			for i, param := range params {
+				scope.vars[param] = args[i]
+			}
+			return n.eval(scope)
+		}}, nil
+	}
+
+	v, err := n.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	f, ok := v.(*funcValue)
+	if !ok {
+		return nil, fmt.Errorf("expected a function, got %s (use x to write a lambda such as x*2)", FormatValue(v))
+	}
+	return f, nil
+}
+
+// mentions reports whether n refers to any of the given names as a variable
+func mentions(n node, names ...string) bool {
+	switch n := n.(type) {
+	case *identNode:
+		for _, name := range names {
+			if n.name == name {
+				return true
+			}
+		}
+	case *unaryNode:
+		return mentions(n.x, names...)
+	case *binaryNode:
+		return mentions(n.l, names...) || mentions(n.r, names...)
+	case *rangeNode:
+		return mentions(n.lo, names...) || mentions(n.hi, names...)
+	case *listNode:
+		for _, elem := range n.elems {
+			if mentions(elem, names...) {
+				return true
+			}
+		}
+	case *callNode:
+		// x in a nested map(x*2, ...) is bound by that call, not free here
+		args := n.args
+		if skip := lambdaArgs[n.name]; len(args) >= skip {
+			args = args[skip:]
+		}
+		for _, arg := range args {
+			if mentions(arg, names...) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lambdaArgs is how many leading arguments of each special form are
+// function positions that bind their own x
+var lambdaArgs = map[string]int{"map": 1, "filter": 1, "reduce": 1, "compose": 2}
+
+// intFn adapts f to func(int) int, recording the first failure in *errp
+func intFn(env *evalEnv, f *funcValue, errp *error) func(int) int {
+	return func(n int) int {
+		if *errp != nil {
+			return 0
+		}
+		v, err := env.call(f, []value{n})
+		if err == nil {
+			if i, ok := v.(int); ok {
+				return i
+			}
+			err = fmt.Errorf("%s must return an integer, got %s", f.name, FormatValue(v))
+		}
+		*errp = err
+		return 0
+	}
+}
+
+// checkArgs validates the argument count of a special form
+func checkArgs(name string, args []node, counts ...int) error {
+	for _, c := range counts {
+		if len(args) == c {
+			return nil
+		}
+	}
+	want := make([]string, len(counts))
+	for i, c := range counts {
+		want[i] = fmt.Sprint(c)
+	}
+	return fmt.Errorf("%s expects %s argument(s), got %d", name, strings.Join(want, " or "), len(args))
+}
+
+// evalMap handles map(f, list) via Apply
+func evalMap(env *evalEnv, args []node) (value, error) {
+	if err := checkArgs("map", args, 2); err != nil {
+		return nil, err
+	}
+	f, err := evalFunc(env, args[0], "x")
+	if err != nil {
+		return nil, err
+	}
+	list, err := evalList(env, args[1], "map's second argument")
+	if err != nil {
+		return nil, err
+	}
+
+	var callErr error
+	result := Apply(list, intFn(env, f, &callErr))
+	return result, callErr
+}
+
+// evalFilter handles filter(predicate, list) via Filter
+func evalFilter(env *evalEnv, args []node) (value, error) {
+	if err := checkArgs("filter", args, 2); err != nil {
+		return nil, err
+	}
+	f, err := evalFunc(env, args[0], "x")
+	if err != nil {
+		return nil, err
+	}
+	list, err := evalList(env, args[1], "filter's second argument")
+	if err != nil {
+		return nil, err
+	}
+
+	var callErr error
+	result := Filter(list, func(n int) bool {
+		if callErr != nil {
+			return false
+		}
+		v, err := env.call(f, []value{n})
+		if err == nil {
+			if b, ok := v.(bool); ok {
+				return b
+			}
+			err = fmt.Errorf("%s must return true or false, got %s", f.name, FormatValue(v))
+		}
+		callErr = err
+		return false
+	})
+	return result, callErr
+}
+
+// evalReduce handles reduce(op, list) and reduce(op, initial, list) via Reduce
+// Without an initial value the first element is used, so the list must not
+// be empty. Lambdas see the running total as acc and the element as x.
+func evalReduce(env *evalEnv, args []node) (value, error) {
+	if err := checkArgs("reduce", args, 2, 3); err != nil {
+		return nil, err
+	}
+	f, err := evalFunc(env, args[0], "acc", "x")
+	if err != nil {
+		return nil, err
+	}
+	list, err := evalList(env, args[len(args)-1], "reduce's last argument")
+	if err != nil {
+		return nil, err
+	}
+
+	var initial int
+	if len(args) == 3 {
+		if initial, err = evalInt(env, args[1], "reduce's initial value"); err != nil {
+			return nil, err
+		}
+	} else {
+		if len(list) == 0 {
+			return nil, errors.New("reduce of an empty list needs an initial value")
+		}
+		initial, list = list[0], list[1:]
+	}
+
+	var callErr error
+	result := Reduce(list, initial, func(acc, current int) int {
+		if callErr != nil {
+			return 0
+		}
+		v, err := env.call(f, []value{acc, current})
+		if err == nil {
+			if i, ok := v.(int); ok {
+				return i
+			}
+			err = fmt.Errorf("%s must return an integer, got %s", f.name, FormatValue(v))
+		}
+		callErr = err
+		return 0
+	})
+	return result, callErr
+}
+
+// evalCompose handles compose(f, g), returning x -> f(g(x)) via Compose
+func evalCompose(env *evalEnv, args []node) (value, error) {
+	if err := checkArgs("compose", args, 2); err != nil {
+		return nil, err
+	}
+	f, err := evalFunc(env, args[0], "x")
+	if err != nil {
+		return nil, err
+	}
+	g, err := evalFunc(env, args[1], "x")
+	if err != nil {
+		return nil, err
+	}
+
+	return &funcValue{name: "compose", call: func(args []value) (value, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("compose expects 1 argument, got %d", len(args))
+		}
+		n, ok := args[0].(int)
+		if !ok {
+			return nil, fmt.Errorf("compose expects an integer, got %s", FormatValue(args[0]))
+		}
+
+		var callErr error
+		result := Compose(intFn(env, f, &callErr), intFn(env, g, &callErr))(n)
+		return result, callErr
+	}}, nil
+}
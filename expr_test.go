@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+/*----- Extension: Expression Language -----*/
+
+// TestTokenize tests splitting source text into tokens
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		want    []string
+		wantErr bool
+	}{
+		{name: "range", src: "1..100", want: []string{"1", "..", "100"}},
+		{name: "call with operator", src: "reduce(+)", want: []string{"reduce", "(", "+", ")"}},
+		{name: "comparisons", src: "x<=3 == y!=4", want: []string{"x", "<=", "3", "==", "y", "!=", "4"}},
+		{name: "whitespace is ignored", src: "  a |\tb ", want: []string{"a", "|", "b"}},
+		{name: "unknown character", src: "2 ^ 3", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := tokenize(tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("tokenize() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			got := []string{}
+			for _, tok := range tokens {
+				if tok.kind != tokEOF {
+					got = append(got, tok.text)
+				}
+			}
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("tokenize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestEvalExpr tests parsing and evaluating complete expressions
+func TestEvalExpr(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		want    string
+		wantErr string
+	}{
+		// Arithmetic and precedence
+		{name: "precedence", src: "2 + 3 * 4", want: "14"},
+		{name: "parentheses", src: "(2 + 3) * 4", want: "20"},
+		{name: "unary minus", src: "-3 - -4", want: "1"},
+		{name: "division and modulo", src: "17 / 5 + 17 % 5", want: "5"},
+		{name: "comparison", src: "3 * 3 > 8", want: "true"},
+
+		// Lists
+		{name: "range", src: "1..5", want: "[1 2 3 4 5]"},
+		{name: "empty range", src: "5..1", want: "[]"},
+		{name: "range ending at MaxInt", src: fmt.Sprintf("%d..%d", math.MaxInt-2, math.MaxInt), want: fmt.Sprint([]int{math.MaxInt - 2, math.MaxInt - 1, math.MaxInt})},
+		{name: "list literal", src: "[3, 1, 2]", want: "[3 1 2]"},
+
+		// Math primitives
+		{name: "factorial", src: "factorial(5)", want: "120"},
+		{name: "power", src: "power(2, 10)", want: "1024"},
+		{name: "isprime", src: "isprime(97)", want: "true"},
+		{name: "isprime below 2 is false", src: "isprime(1)", want: "false"},
+
+		// Higher-order functions
+		{name: "map lambda", src: "map(x*x, 1..5)", want: "[1 4 9 16 25]"},
+		{name: "map builtin", src: "map(factorial, [3, 4])", want: "[6 24]"},
+		{name: "filter lambda", src: "filter(x > 5, 1..8)", want: "[6 7 8]"},
+		{name: "filter builtin", src: "filter(isprime, 1..20)", want: "[2 3 5 7 11 13 17 19]"},
+		{name: "reduce operator", src: "reduce(+, 1..10)", want: "55"},
+		{name: "reduce with initial", src: "reduce(*, 1, [])", want: "1"},
+		{name: "reduce lambda", src: "reduce(acc*10 + x, 0, [1, 2, 3])", want: "123"},
+		{name: "compose", src: "map(compose(x+10, x*2), [6])", want: "[22]"},
+		{name: "compose of builtins", src: "map(compose(factorial, x+1), [2, 3])", want: "[6 24]"},
+
+		// Pipes
+		{name: "request example", src: "map(x*x, filter(isprime, 1..100)) | reduce(+)", want: "65796"},
+		{name: "pipe chain", src: "1..10 | filter(x % 2 == 0) | map(x / 2) | reduce(+)", want: "15"},
+		{name: "pipe into bare name", src: "5 | factorial", want: "120"},
+
+		// Errors
+		{name: "library error surfaces", src: "factorial(-1)", wantErr: "negative numbers"},
+		{name: "library error inside map", src: "map(power(2, x), [1, -1])", wantErr: "negative exponents"},
+		{name: "division by zero", src: "1 / 0", wantErr: "division by zero"},
+		{name: "reduce of empty list", src: "reduce(+, [])", wantErr: "initial value"},
+		{name: "filter needs bool", src: "filter(x + 1, [1])", wantErr: "true or false"},
+		{name: "map needs a list", src: "map(x, 3)", wantErr: "must be a list"},
+		{name: "not a function", src: "map(3, [1])", wantErr: "expected a function"},
+		{name: "undefined name", src: "sqrt(4)", wantErr: "undefined name"},
+		{name: "wrong arity", src: "power(2)", wantErr: "expects 2 argument(s)"},
+		{name: "syntax error", src: "1 +", wantErr: "end of input at column 4"},
+		{name: "unbalanced paren", src: "(1 + 2", wantErr: `expected ")"`},
+		{name: "trailing tokens", src: "1 2", wantErr: `unexpected "2" at column 3`},
+		{name: "bad pipe target", src: "1 | 2", wantErr: "right side of |"},
+		{name: "huge range", src: "1..100000000", wantErr: "longer than"},
+		{name: "range spanning zero overflows int", src: fmt.Sprintf("%d..%d", -math.MaxInt, math.MaxInt), wantErr: "longer than"},
+		{name: "factorial overflow", src: "factorial(25)", wantErr: "overflows"},
+		{name: "factorial of huge n", src: fmt.Sprintf("factorial(%d)", math.MaxInt), wantErr: "overflows"},
+		{name: "power overflow", src: fmt.Sprintf("power(2, %d)", math.MaxInt), wantErr: "overflows"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EvalExpr(tt.src)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("EvalExpr(%q) error = %v, want it to contain %q", tt.src, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("EvalExpr(%q) error = %v", tt.src, err)
+				return
+			}
+			if FormatValue(got) != tt.want {
+				t.Errorf("EvalExpr(%q) = %v, want %v", tt.src, FormatValue(got), tt.want)
+			}
+		})
+	}
+}
+
+// TestEvalExprContext tests the step budget and cancellation used for
+// untrusted expressions
+func TestEvalExprContext(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		src      string
+		maxSteps int
+		want     string
+		wantErr  error
+	}{
+		{name: "within budget", ctx: context.Background(), src: "map(x*x, 1..4) | reduce(+)", maxSteps: 100, want: "30"},
+		{name: "range over budget", ctx: context.Background(), src: "1..1000", maxSteps: 100, wantErr: ErrEvalLimit},
+		{name: "nested work over budget", ctx: context.Background(), src: "map(reduce(+, 1..x), 1..10000)", maxSteps: 1_000_000, wantErr: ErrEvalLimit},
+		{name: "isprime charges trial divisions", ctx: context.Background(), src: "isprime(2147483647)", maxSteps: 10_000, wantErr: ErrEvalLimit},
+		{name: "canceled context", ctx: canceled, src: "1 + 1", maxSteps: 100, wantErr: context.Canceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EvalExprContext(tt.ctx, tt.src, tt.maxSteps)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("EvalExprContext(%q) error = %v, want %v", tt.src, err, tt.wantErr)
+			}
+			if tt.wantErr == nil && FormatValue(got) != tt.want {
+				t.Errorf("EvalExprContext(%q) = %v, want %v", tt.src, FormatValue(got), tt.want)
+			}
+		})
+	}
+}
+
+// TestRunEval tests the eval subcommand
+func TestRunEval(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"eval", "reduce(+,", "1..4)"}, &stdout, &stderr); code != exitOK {
+		t.Fatalf("run(eval) exit code = %d, want %d (stderr: %s)", code, exitOK, stderr.String())
+	}
+	if stdout.String() != "10\n" {
+		t.Errorf("run(eval) stdout = %q, want %q", stdout.String(), "10\n")
+	}
+
+	if code := run([]string{"eval"}, &stdout, &stderr); code != exitUsage {
+		t.Errorf("run(eval) without expression exit code = %d, want %d", code, exitUsage)
+	}
+}
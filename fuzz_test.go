@@ -0,0 +1,194 @@
+package main
+
+import (
+	"encoding/binary"
+	"math"
+	"math/big"
+	"testing"
+)
+
+/*----- Fuzz Tests -----*/
+
+// Limits keep each fuzz input fast; the functions loop once per unit of n,
+// exponent or sqrt(n). Every 32-bit int is fast enough for IsPrime.
+const (
+	maxFuzzFactorial = 2_000
+	maxFuzzExponent  = 2_000
+	maxFuzzPrime     = min(1_000_000_000_000, math.MaxInt)
+)
+
+// wrapInt reduces x to the int that overflowing int arithmetic produces,
+// i.e. its low 32 or 64 bits read as two's complement
+func wrapInt(x *big.Int) int {
+	low := new(big.Int).And(x, new(big.Int).SetUint64(^uint64(0)))
+	return int(low.Uint64()) // the conversion keeps only the low bits of int
+}
+
+// fitsInt reports whether x is representable as int without wrapping
+func fitsInt(x *big.Int) bool {
+	return x.IsInt64() && !is64BitOnly(x.Int64())
+}
+
+// 1. Factorial
+func FuzzFactorial(f *testing.F) {
+	f.Fuzz(func(t *testing.T, n int) {
+		if n > maxFuzzFactorial {
+			t.Skip("n too large to check quickly")
+		}
+
+		got, err := Factorial(n)
+		if n < 0 {
+			if err == nil {
+				t.Fatalf("Factorial(%d) = %d, want an error", n, got)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("Factorial(%d) error = %v", n, err)
+		}
+
+		want := new(big.Int).MulRange(1, int64(n))
+		if got != wrapInt(want) {
+			t.Fatalf("Factorial(%d) = %d, want %d (big %v)", n, got, wrapInt(want), want)
+		}
+		if fitsInt(want) && n > 0 {
+			prev, _ := Factorial(n - 1)
+			if got != prev*n {
+				t.Fatalf("Factorial(%d) = %d, want Factorial(%d)*%d = %d", n, got, n-1, n, prev*n)
+			}
+		}
+	})
+}
+
+// 2. IsPrime
+func FuzzIsPrime(f *testing.F) {
+	f.Fuzz(func(t *testing.T, n int) {
+		if n > maxFuzzPrime {
+			t.Skip("n too large to check quickly")
+		}
+
+		got, err := IsPrime(n)
+		if n < 2 {
+			if err == nil {
+				t.Fatalf("IsPrime(%d) = %v, want an error", n, got)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("IsPrime(%d) error = %v", n, err)
+		}
+
+		// ProbablyPrime is exact for every input below 2^64
+		if want := big.NewInt(int64(n)).ProbablyPrime(0); got != want {
+			t.Fatalf("IsPrime(%d) = %v, big.Int.ProbablyPrime = %v", n, got, want)
+		}
+	})
+}
+
+// 3. Power
+func FuzzPower(f *testing.F) {
+	f.Fuzz(func(t *testing.T, base, exponent int) {
+		if exponent > maxFuzzExponent {
+			t.Skip("exponent too large to check quickly")
+		}
+
+		got, err := Power(base, exponent)
+		if exponent < 0 {
+			if err == nil {
+				t.Fatalf("Power(%d, %d) = %d, want an error", base, exponent, got)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("Power(%d, %d) error = %v", base, exponent, err)
+		}
+
+		want := new(big.Int).Exp(big.NewInt(int64(base)), big.NewInt(int64(exponent)), nil)
+		if got != wrapInt(want) {
+			t.Fatalf("Power(%d, %d) = %d, want %d (big %v)", base, exponent, got, wrapInt(want), want)
+		}
+
+		// Power(b, e+1) == Power(b, e)*b whenever the larger result fits
+		next := new(big.Int).Mul(want, big.NewInt(int64(base)))
+		if fitsInt(next) {
+			gotNext, _ := Power(base, exponent+1)
+			if gotNext != got*base {
+				t.Fatalf("Power(%d, %d) = %d, want Power(%d, %d)*%d = %d", base, exponent+1, gotNext, base, exponent, base, got*base)
+			}
+		}
+	})
+}
+
+// fuzzOp is one Pipeline stage with its math/big reference
+type fuzzOp struct {
+	fn  func(int) int
+	ref func(x *big.Int)
+}
+
+// decodeFuzzOp turns a byte into a stage: the top two bits pick the
+// operation and the rest is a small signed operand
+func decodeFuzzOp(b byte) fuzzOp {
+	k := int(b&0x3f) - 32
+	bigK := big.NewInt(int64(k))
+	switch b >> 6 {
+	case 0:
+		return fuzzOp{fn: func(x int) int { return x + k }, ref: func(x *big.Int) { x.Add(x, bigK) }}
+	case 1:
+		return fuzzOp{fn: func(x int) int { return x * k }, ref: func(x *big.Int) { x.Mul(x, bigK) }}
+	case 2:
+		return fuzzOp{fn: func(x int) int { return x * x }, ref: func(x *big.Int) { x.Mul(x, x) }}
+	default:
+		return fuzzOp{fn: func(x int) int { return -x }, ref: func(x *big.Int) { x.Neg(x) }}
+	}
+}
+
+// 4. Pipeline
+func FuzzPipeline(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data, stages []byte) {
+		nums := make([]int, len(data)/8)
+		for i := range nums {
+			nums[i] = int(binary.LittleEndian.Uint64(data[i*8:]))
+		}
+		ops := make([]fuzzOp, len(stages))
+		operations := make([]func(int) int, len(stages))
+		for i, b := range stages {
+			ops[i] = decodeFuzzOp(b)
+			operations[i] = ops[i].fn
+		}
+		input := append([]int(nil), nums...)
+
+		got := Pipeline(nums, operations...)
+		if len(got) != len(nums) {
+			t.Fatalf("Pipeline() returned %d values for %d inputs", len(got), len(nums))
+		}
+		for i := range nums {
+			if nums[i] != input[i] {
+				t.Fatalf("Pipeline() modified its input at %d: %d -> %d", i, input[i], nums[i])
+			}
+		}
+
+		for i, n := range nums {
+			// Wrapping each step keeps the reference small, and gives the
+			// same low 64 bits as wrapping once at the end
+			want := big.NewInt(int64(n))
+			for _, op := range ops {
+				op.ref(want)
+				want.SetInt64(int64(wrapInt(want)))
+			}
+			if got[i] != wrapInt(want) {
+				t.Fatalf("Pipeline()[%d] = %d, want %d", i, got[i], wrapInt(want))
+			}
+		}
+
+		// Running the stages one Apply at a time must agree
+		staged := nums
+		for _, op := range operations {
+			staged = Apply(staged, op)
+		}
+		for i := range got {
+			if got[i] != staged[i] {
+				t.Fatalf("Pipeline()[%d] = %d, chained Apply = %d", i, got[i], staged[i])
+			}
+		}
+	})
+}
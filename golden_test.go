@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+/*----- Golden Files: Demo Output -----*/
+
+// Run `go test -run TestDemoGolden -update` after an intended output change
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+
+// scrubber rewrites the nondeterministic parts of one line of demo output
+type scrubber func(line string) string
+
+// redactValue replaces everything after prefix on lines that start with it
+func redactValue(prefix string) scrubber {
+	return func(line string) string {
+		if strings.HasPrefix(line, prefix) {
+			return prefix + " <redacted>"
+		}
+		return line
+	}
+}
+
+// redactPattern replaces every match of pattern with replacement
+func redactPattern(pattern, replacement string) scrubber {
+	re := regexp.MustCompile(pattern)
+	return func(line string) string {
+		return re.ReplaceAllString(line, replacement)
+	}
+}
+
+// scrubLines applies each scrubber, in order, to every line of out
+func scrubLines(out string, scrubbers []scrubber) string {
+	lines := strings.Split(out, "\n")
+	for i, line := range lines {
+		for _, scrub := range scrubbers {
+			line = scrub(line)
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// sectionScrubbers lists the scrubbers for each demo section; sections
+// without an entry must be fully deterministic
+var sectionScrubbers = map[string][]scrubber{
+	"process": {
+		redactValue("Current Process ID:"),
+		redactValue("Parent Process ID:"),
+		redactValue("User ID / Group ID:"),
+		redactValue("Executable:"),
+		redactValue("Working directory:"),
+		redactValue("Command line:"),
+		redactValue("Environment variables:"),
+		redactValue("Started at:"),
+		redactValue("Threads:"),
+		redactValue("Resident memory (RSS):"),
+		redactValue("Virtual memory (VSZ):"),
+		redactValue("Memory address of slice:"),
+		redactValue("Memory address of first element:"),
+		// Which region an address lands in depends on the runtime version
+		redactValue("  slice header:"),
+		redactValue("  first element:"),
+		redactValue("  ExploreProcess code:"),
+		// Word sizes and alignment differ between 32- and 64-bit platforms
+		redactValue("ProcessInfo struct:"),
+	},
+}
+
+func TestDemoGolden(t *testing.T) {
+	for _, section := range demoSections {
+		t.Run(section.name, func(t *testing.T) {
+			if section.name == "process" && runtime.GOOS != "linux" {
+				t.Skip("the process section reads /proc")
+			}
+
+			var buf bytes.Buffer
+			writeDemoText(&buf, section.records())
+			got := scrubLines(buf.String(), sectionScrubbers[section.name])
+
+			path := filepath.Join("testdata", "golden", section.name+".golden")
+			if *update {
+				if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("%v (run with -update to create it)", err)
+			}
+			if got != string(want) {
+				t.Errorf("%s output differs from %s (run with -update if intended):\n%s", section.name, path, firstDifference(string(want), got))
+			}
+		})
+	}
+}
+
+// The same section must produce the same scrubbed output every time
+func TestDemoSectionsAreReproducible(t *testing.T) {
+	for _, section := range demoSections {
+		var first, second bytes.Buffer
+		writeDemoText(&first, section.records())
+		writeDemoText(&second, section.records())
+		scrubbers := sectionScrubbers[section.name]
+		if a, b := scrubLines(first.String(), scrubbers), scrubLines(second.String(), scrubbers); a != b {
+			t.Errorf("%s output changes between runs:\n%s", section.name, firstDifference(a, b))
+		}
+	}
+}
+
+func TestScrubbers(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        string
+		scrubbers []scrubber
+		want      string
+	}{
+		{name: "no scrubbers", in: "PID: 42\n", scrubbers: nil, want: "PID: 42\n"},
+		{name: "value after prefix", in: "PID: 42\nName: x\n", scrubbers: []scrubber{redactValue("PID:")}, want: "PID: <redacted>\nName: x\n"},
+		{name: "prefix must start the line", in: "my PID: 42", scrubbers: []scrubber{redactValue("PID:")}, want: "my PID: 42"},
+		{name: "pattern", in: "at 0xc000012345 and 0x1f", scrubbers: []scrubber{redactPattern(`0x[0-9a-f]+`, "0xADDR")}, want: "at 0xADDR and 0xADDR"},
+		{
+			name:      "applied in order",
+			in:        "Addr: 0x10",
+			scrubbers: []scrubber{redactPattern(`0x[0-9a-f]+`, "ADDR"), redactValue("Addr:")},
+			want:      "Addr: <redacted>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scrubLines(tt.in, tt.scrubbers); got != tt.want {
+				t.Errorf("scrubLines() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// firstDifference describes the first line where want and got disagree
+func firstDifference(want, got string) string {
+	wantLines, gotLines := strings.Split(want, "\n"), strings.Split(got, "\n")
+	for i := 0; i < max(len(wantLines), len(gotLines)); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g {
+			return "line " + strconv.Itoa(i+1) + ":\n  want: " + strconv.Quote(w) + "\n  got:  " + strconv.Quote(g)
+		}
+	}
+	return "(no difference)"
+}
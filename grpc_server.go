@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+
+	"github.com/ErvinLinUB/go-advanced-lab/analyzerpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+/*----- Extension: gRPC Service -----*/
+
+// grpcServer implements analyzerpb.AnalyzerServer over the library functions
+// Library errors come from invalid input, so they map to INVALID_ARGUMENT.
+// Inputs are bounded by the same limits as the JSON API, and expressions by
+// limits.
+type grpcServer struct {
+	analyzerpb.UnimplementedAnalyzerServer
+	limits evalLimits
+}
+
+// newGRPCServer creates a gRPC server with the Analyzer service registered,
+// evaluating Eval expressions within limits
+func newGRPCServer(limits evalLimits) *grpc.Server {
+	server := grpc.NewServer()
+	analyzerpb.RegisterAnalyzerServer(server, grpcServer{limits: limits})
+	return server
+}
+
+// Factorial implements analyzerpb.AnalyzerServer
+func (grpcServer) Factorial(ctx context.Context, req *analyzerpb.FactorialRequest) (*analyzerpb.FactorialResponse, error) {
+	result, err := checkedFactorial(int(req.GetN()))
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	return &analyzerpb.FactorialResponse{Result: int64(result)}, nil
+}
+
+// IsPrime implements analyzerpb.AnalyzerServer
+func (grpcServer) IsPrime(ctx context.Context, req *analyzerpb.IsPrimeRequest) (*analyzerpb.IsPrimeResponse, error) {
+	if err := checkTrialDivision(ctx, req.GetN()); err != nil {
+		return nil, err
+	}
+	prime, err := IsPrime(int(req.GetN()))
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	return &analyzerpb.IsPrimeResponse{Prime: prime}, nil
+}
+
+// Power implements analyzerpb.AnalyzerServer
+func (grpcServer) Power(ctx context.Context, req *analyzerpb.PowerRequest) (*analyzerpb.PowerResponse, error) {
+	result, err := checkedPower(int(req.GetBase()), int(req.GetExponent()))
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	return &analyzerpb.PowerResponse{Result: int64(result)}, nil
+}
+
+// Factorize implements analyzerpb.AnalyzerServer
+func (grpcServer) Factorize(ctx context.Context, req *analyzerpb.FactorizeRequest) (*analyzerpb.FactorizeResponse, error) {
+	if err := checkTrialDivision(ctx, req.GetN()); err != nil {
+		return nil, err
+	}
+	factors, err := Factorize(int(req.GetN()))
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	return &analyzerpb.FactorizeResponse{Factors: toInt64s(factors)}, nil
+}
+
+// PrimesUpTo implements analyzerpb.AnalyzerServer, sending one message per prime
+func (grpcServer) PrimesUpTo(req *analyzerpb.PrimesUpToRequest, stream grpc.ServerStreamingServer[analyzerpb.Prime]) error {
+	if req.GetUpto() > maxPrimesLimit {
+		return status.Errorf(codes.InvalidArgument, "upto must be at most %d", maxPrimesLimit)
+	}
+
+	primes, err := PrimesUpTo(int(req.GetUpto()))
+	if err != nil {
+		return invalidArgument(err)
+	}
+	for _, p := range primes {
+		if err := stream.Send(&analyzerpb.Prime{Value: int64(p)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Eval implements analyzerpb.AnalyzerServer
+func (s grpcServer) Eval(ctx context.Context, req *analyzerpb.EvalRequest) (*analyzerpb.EvalResponse, error) {
+	result, err := s.limits.eval(ctx, req.GetExpression())
+	switch {
+	case errors.Is(err, ErrEvalLimit):
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return nil, status.FromContextError(err).Err()
+	case err != nil:
+		return nil, invalidArgument(err)
+	}
+
+	switch v := result.(type) {
+	case int:
+		return &analyzerpb.EvalResponse{Result: &analyzerpb.EvalResponse_Number{Number: int64(v)}}, nil
+	case bool:
+		return &analyzerpb.EvalResponse{Result: &analyzerpb.EvalResponse_Boolean{Boolean: v}}, nil
+	case []int:
+		list := &analyzerpb.IntList{Values: toInt64s(v)}
+		return &analyzerpb.EvalResponse{Result: &analyzerpb.EvalResponse_List{List: list}}, nil
+	}
+	return nil, status.Errorf(codes.InvalidArgument, "expression must evaluate to a number, boolean or list, got %s", FormatValue(result))
+}
+
+// checkTrialDivision rejects numbers too large to test by trial division
+// within one request, and requests whose caller has already gone away
+func checkTrialDivision(ctx context.Context, n int64) error {
+	if err := ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	if n > maxPrimeCheck {
+		return status.Errorf(codes.InvalidArgument, "n must be at most %d", maxPrimeCheck)
+	}
+	return nil
+}
+
+// invalidArgument wraps a library error in a gRPC status
+func invalidArgument(err error) error {
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+// toInt64s converts a result slice to its protobuf representation
+func toInt64s(nums []int) []int64 {
+	out := make([]int64, len(nums))
+	for i, n := range nums {
+		out[i] = int64(n)
+	}
+	return out
+}
+
+// runGRPC handles `analyzer grpc [--addr localhost:9090]`, stopping on Ctrl-C
+func runGRPC(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("grpc", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	addr := flags.String("addr", "localhost:9090", "address to listen on")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if flags.NArg() != 0 {
+		return fmt.Errorf("%w: unexpected argument %q", errUsage, flags.Arg(0))
+	}
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	server := newGRPCServer(defaultEvalLimits)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		server.GracefulStop()
+	}()
+
+	fmt.Fprintln(stdout, "analyzer: serving gRPC on", listener.Addr())
+	if err := server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ErvinLinUB/go-advanced-lab/analyzerclient"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+/*----- Extension: gRPC Service -----*/
+
+// newTestClient serves the Analyzer service over an in-memory listener
+func newTestClient(t *testing.T, limits evalLimits) *analyzerclient.Client {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := newGRPCServer(limits)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	client, err := analyzerclient.Dial("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// aboveMaxPrimeCheck is a variable so the cases using it compile on 32-bit
+// builds, where they are skipped
+var aboveMaxPrimeCheck = maxPrimeCheck + 1
+
+// TestGRPCUnary tests every unary RPC through the client package
+// As in TestAPIHandler, the step budget is small and the deadline long.
+func TestGRPCUnary(t *testing.T) {
+	client := newTestClient(t, evalLimits{steps: 100_000, timeout: time.Minute})
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		call     func() (any, error)
+		want     any
+		wantCode codes.Code
+		needs64  bool
+	}{
+		{name: "factorial", call: func() (any, error) { return client.Factorial(ctx, 10) }, want: 3628800},
+		{name: "factorial negative", call: func() (any, error) { return client.Factorial(ctx, -1) }, wantCode: codes.InvalidArgument},
+		{name: "factorial overflow", call: func() (any, error) { return client.Factorial(ctx, 25) }, wantCode: codes.InvalidArgument},
+		{name: "isprime", call: func() (any, error) { return client.IsPrime(ctx, 97) }, want: true},
+		{name: "isprime composite", call: func() (any, error) { return client.IsPrime(ctx, 91) }, want: false},
+		{name: "isprime above limit", call: func() (any, error) { return client.IsPrime(ctx, int(aboveMaxPrimeCheck)) }, wantCode: codes.InvalidArgument, needs64: true},
+		{name: "isprime below 2", call: func() (any, error) { return client.IsPrime(ctx, 0) }, wantCode: codes.InvalidArgument},
+		{name: "power", call: func() (any, error) { return client.Power(ctx, 3, 4) }, want: 81},
+		{name: "power negative exponent", call: func() (any, error) { return client.Power(ctx, 3, -4) }, wantCode: codes.InvalidArgument},
+		{name: "factorize", call: func() (any, error) { return client.Factorize(ctx, 360) }, want: []int{2, 2, 2, 3, 3, 5}},
+		{name: "factorize above limit", call: func() (any, error) { return client.Factorize(ctx, int(aboveMaxPrimeCheck)) }, wantCode: codes.InvalidArgument, needs64: true},
+		{name: "factorize 1", call: func() (any, error) { return client.Factorize(ctx, 1) }, wantCode: codes.InvalidArgument},
+		{name: "eval number", call: func() (any, error) { return client.Eval(ctx, "reduce(+, 1..100)") }, want: 5050},
+		{name: "eval boolean", call: func() (any, error) { return client.Eval(ctx, "isprime(13)") }, want: true},
+		{name: "eval list", call: func() (any, error) { return client.Eval(ctx, "map(x*x, 1..3)") }, want: []int{1, 4, 9}},
+		{name: "eval empty list", call: func() (any, error) { return client.Eval(ctx, "filter(x > 9, 1..3)") }, want: []int{}},
+		{name: "eval error", call: func() (any, error) { return client.Eval(ctx, "1 +") }, wantCode: codes.InvalidArgument},
+		{name: "eval over step limit", call: func() (any, error) { return client.Eval(ctx, "map(reduce(+, 1..x), 1..1000000)") }, wantCode: codes.ResourceExhausted},
+		{name: "eval function", call: func() (any, error) { return client.Eval(ctx, "counter(1)") }, wantCode: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.needs64 && is64BitOnly(aboveMaxPrimeCheck) {
+				t.Skipf("%d does not fit in a %d-bit int", aboveMaxPrimeCheck, strconv.IntSize)
+			}
+			got, err := tt.call()
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("error = %v, want code %v", err, tt.wantCode)
+			}
+			if err != nil {
+				return
+			}
+
+			gotList, isList := got.([]int)
+			wantList, wantIsList := tt.want.([]int)
+			if isList || wantIsList {
+				if !slices.Equal(gotList, wantList) {
+					t.Errorf("got %v, want %v", got, tt.want)
+				}
+			} else if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestGRPCPrimesUpTo tests the server-streaming RPC
+func TestGRPCPrimesUpTo(t *testing.T) {
+	client := newTestClient(t, defaultEvalLimits)
+	ctx := context.Background()
+
+	t.Run("streams every prime", func(t *testing.T) {
+		var got []int
+		err := client.PrimesUpTo(ctx, 30, func(p int) error {
+			got = append(got, p)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("PrimesUpTo() error = %v", err)
+		}
+		want := []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}
+		if !slices.Equal(got, want) {
+			t.Errorf("PrimesUpTo() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("callback error stops the stream", func(t *testing.T) {
+		errStop := errors.New("stop")
+		count := 0
+		err := client.PrimesUpTo(ctx, 1_000_000, func(p int) error {
+			count++
+			if count == 5 {
+				return errStop
+			}
+			return nil
+		})
+		if !errors.Is(err, errStop) || count != 5 {
+			t.Errorf("PrimesUpTo() error = %v after %d primes, want errStop after 5", err, count)
+		}
+	})
+
+	t.Run("invalid limits", func(t *testing.T) {
+		noop := func(int) error { return nil }
+		if err := client.PrimesUpTo(ctx, -1, noop); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("PrimesUpTo(-1) error = %v, want InvalidArgument", err)
+		}
+		if err := client.PrimesUpTo(ctx, maxPrimesLimit+1, noop); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("PrimesUpTo(limit+1) error = %v, want InvalidArgument", err)
+		}
+	})
+}
+
+// TestGRPCEvalTimeout tests that an expression still running at its
+// deadline fails with DEADLINE_EXCEEDED
+func TestGRPCEvalTimeout(t *testing.T) {
+	client := newTestClient(t, evalLimits{steps: maxEvalSteps, timeout: time.Nanosecond})
+
+	_, err := client.Eval(context.Background(), "map(reduce(+, 1..x), 1..1000)")
+	if code := status.Code(err); code != codes.DeadlineExceeded {
+		t.Errorf("Eval() error = %v, want code %v", err, codes.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,376 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	goparser "go/parser"
+	gotoken "go/token"
+	"go/types"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+/*----- Extension: Struct Layout Inspector -----*/
+
+// FieldLayout is where one field sits inside its struct
+type FieldLayout struct {
+	Name    string
+	Type    string
+	Offset  int64
+	Size    int64
+	Align   int64
+	Padding int64 // unused bytes between this field and the next one (or the end)
+}
+
+// StructLayout describes a struct's memory layout and a tighter field order
+type StructLayout struct {
+	Name    string
+	Size    int64
+	Align   int64
+	Padding int64 // total unused bytes
+	Fields  []FieldLayout
+
+	// Suggested is a field order with less padding, and SuggestedSize its
+	// size; Suggested is nil when the current order is already optimal
+	Suggested     []string
+	SuggestedSize int64
+}
+
+// 1. InspectLayout - reports the layout of a struct, or of the struct a
+// pointer points to, as the running program lays it out
+func InspectLayout(v any) (StructLayout, error) {
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return StructLayout{}, fmt.Errorf("InspectLayout needs a struct or pointer to struct, got %v", t)
+	}
+
+	fields := make([]FieldLayout, t.NumField())
+	for i := range fields {
+		f := t.Field(i)
+		fields[i] = FieldLayout{
+			Name:   f.Name,
+			Type:   f.Type.String(),
+			Offset: int64(f.Offset),
+			Size:   int64(f.Type.Size()),
+			Align:  int64(f.Type.FieldAlign()),
+		}
+	}
+	return newStructLayout(t.String(), int64(t.Size()), int64(t.Align()), fields), nil
+}
+
+// newStructLayout fills in padding and the suggested order for fields whose
+// offsets are already known
+func newStructLayout(name string, size, align int64, fields []FieldLayout) StructLayout {
+	l := StructLayout{Name: name, Size: size, Align: align, Fields: fields}
+	for i := range fields {
+		end := size
+		if i+1 < len(fields) {
+			end = fields[i+1].Offset
+		}
+		fields[i].Padding = end - fields[i].Offset - fields[i].Size
+		l.Padding += fields[i].Padding
+	}
+	if len(fields) > 0 {
+		l.Padding += fields[0].Offset // always 0 in practice
+	}
+
+	// Zero-sized fields go first, since a trailing one costs padding, and
+	// the rest are ordered by decreasing alignment so no gaps are needed
+	order := make([]FieldLayout, len(fields))
+	copy(order, fields)
+	sort.SliceStable(order, func(i, j int) bool {
+		if (order[i].Size == 0) != (order[j].Size == 0) {
+			return order[i].Size == 0
+		}
+		return order[i].Align > order[j].Align
+	})
+	if suggested := simulateSize(order, align); suggested < size {
+		l.SuggestedSize = suggested
+		for _, f := range order {
+			l.Suggested = append(l.Suggested, f.Name)
+		}
+	}
+	return l
+}
+
+// simulateSize computes the size the gc compiler gives fields in this order
+func simulateSize(fields []FieldLayout, align int64) int64 {
+	var offset int64
+	for _, f := range fields {
+		offset = alignUp(offset, f.Align)
+		offset += f.Size
+	}
+	// A trailing zero-sized field would point past the end of the struct,
+	// so the compiler adds a byte to keep it inside
+	if n := len(fields); n > 0 && fields[n-1].Size == 0 && offset > 0 {
+		offset++
+	}
+	return alignUp(offset, align)
+}
+
+// alignUp rounds n up to a multiple of align
+func alignUp(n, align int64) int64 {
+	if align <= 1 {
+		return n
+	}
+	return (n + align - 1) / align * align
+}
+
+// String renders the layout as a table followed by the suggestion
+func (l StructLayout) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: size %d, align %d, padding %d\n", l.Name, l.Size, l.Align, l.Padding)
+
+	tw := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "  OFFSET\tSIZE\tALIGN\tPAD\tFIELD\tTYPE")
+	for _, f := range l.Fields {
+		fmt.Fprintf(tw, "  %d\t%d\t%d\t%d\t%s\t%s\n", f.Offset, f.Size, f.Align, f.Padding, f.Name, f.Type)
+	}
+	tw.Flush()
+
+	if l.Suggested != nil {
+		fmt.Fprintf(&b, "  suggested order: %s (size %d, saves %d bytes)\n",
+			strings.Join(l.Suggested, ", "), l.SuggestedSize, l.Size-l.SuggestedSize)
+	}
+	return b.String()
+}
+
+// 2. PackageLayouts - type-checks the Go package in dir and reports every
+// struct type it declares, sorted by name, for the given architecture
+// Imports are resolved from compiled export data when available and from
+// source otherwise.
+func PackageLayouts(dir, arch string) ([]StructLayout, error) {
+	sizes := types.SizesFor("gc", arch)
+	if sizes == nil {
+		return nil, fmt.Errorf("unknown architecture %q", arch)
+	}
+
+	fset := gotoken.NewFileSet()
+	name, files, err := parsePackageDir(fset, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var typeErrs []error
+	conf := types.Config{
+		Importer: newLayoutImporter(fset, dir),
+		Sizes:    sizes,
+		// Keep going past type errors to report what can be type-checked
+		Error: func(err error) { typeErrs = append(typeErrs, err) },
+	}
+	pkg, _ := conf.Check(name, fset, files, nil)
+	if pkg == nil {
+		return nil, fmt.Errorf("type-checking failed: %w", errors.Join(typeErrs...))
+	}
+
+	var layouts []StructLayout
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		obj, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || obj.IsAlias() {
+			continue
+		}
+		// A generic struct has no layout until its type parameters are known
+		if named, ok := obj.Type().(*types.Named); ok && named.TypeParams().Len() > 0 {
+			continue
+		}
+		st, ok := obj.Type().Underlying().(*types.Struct)
+		if !ok || !validStruct(st) {
+			continue
+		}
+		layouts = append(layouts, typesLayout(name, pkg, st, sizes))
+	}
+	return layouts, nil
+}
+
+// parsePackageDir parses the non-test Go files of the package in dir that
+// build for the current platform, returning the package name
+// Syntax errors from every file are reported, not just the first file's.
+func parsePackageDir(fset *gotoken.FileSet, dir string) (string, []*ast.File, error) {
+	bp, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return "", nil, err
+	}
+
+	files := make([]*ast.File, 0, len(bp.GoFiles))
+	var parseErrs []error
+	for _, name := range bp.GoFiles {
+		f, err := goparser.ParseFile(fset, filepath.Join(dir, name), nil, goparser.AllErrors)
+		if err != nil {
+			parseErrs = append(parseErrs, err)
+			continue
+		}
+		files = append(files, f)
+	}
+	if len(parseErrs) > 0 {
+		return "", nil, errors.Join(parseErrs...)
+	}
+	return bp.Name, files, nil
+}
+
+// layoutImporter reads imports from the export data `go list -export`
+// leaves in the build cache, which is much faster than type-checking every
+// dependency from source; the source importer is the fallback
+type layoutImporter struct {
+	compiled, source types.Importer
+}
+
+// newLayoutImporter lists the export data for everything dir depends on
+func newLayoutImporter(fset *gotoken.FileSet, dir string) layoutImporter {
+	exports := make(map[string]string)
+	cmd := exec.Command("go", "list", "-export", "-deps", "-f", "{{.ImportPath}}\t{{.Export}}", ".")
+	cmd.Dir = dir
+	if out, err := cmd.Output(); err == nil {
+		for _, line := range strings.Split(string(out), "\n") {
+			if path, file, ok := strings.Cut(line, "\t"); ok && file != "" {
+				exports[path] = file
+			}
+		}
+	}
+
+	lookup := func(path string) (io.ReadCloser, error) {
+		file, ok := exports[path]
+		if !ok {
+			return nil, fmt.Errorf("no export data for %s", path)
+		}
+		return os.Open(file)
+	}
+	return layoutImporter{
+		compiled: importer.ForCompiler(fset, "gc", lookup),
+		source:   importer.ForCompiler(fset, "source", nil),
+	}
+}
+
+func (im layoutImporter) Import(path string) (*types.Package, error) {
+	if pkg, err := im.compiled.Import(path); err == nil {
+		return pkg, nil
+	}
+	return im.source.Import(path)
+}
+
+// validStruct reports whether every field type was resolved
+func validStruct(st *types.Struct) bool {
+	for i := 0; i < st.NumFields(); i++ {
+		if !validType(st.Field(i).Type(), make(map[types.Type]bool)) {
+			return false
+		}
+	}
+	return true
+}
+
+// validType reports whether t contains no invalid (unresolved) types
+func validType(t types.Type, seen map[types.Type]bool) bool {
+	if seen[t] {
+		return true
+	}
+	seen[t] = true
+
+	switch t := t.(type) {
+	case *types.Basic:
+		return t.Kind() != types.Invalid
+	case *types.Array:
+		return validType(t.Elem(), seen)
+	case *types.Named:
+		return validType(t.Underlying(), seen)
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if !validType(t.Field(i).Type(), seen) {
+				return false
+			}
+		}
+	}
+	// Pointers, slices, maps and the like have a fixed size regardless
+	return true
+}
+
+// typesLayout is InspectLayout for a type-checked struct declared in pkg
+func typesLayout(name string, pkg *types.Package, st *types.Struct, sizes types.Sizes) StructLayout {
+	// Name other packages the way source code does, e.g. time.Time
+	qualifier := func(p *types.Package) string {
+		if p == pkg {
+			return ""
+		}
+		return p.Name()
+	}
+
+	vars := make([]*types.Var, st.NumFields())
+	for i := range vars {
+		vars[i] = st.Field(i)
+	}
+	offsets := sizes.Offsetsof(vars)
+
+	fields := make([]FieldLayout, len(vars))
+	for i, v := range vars {
+		fields[i] = FieldLayout{
+			Name:   v.Name(),
+			Type:   types.TypeString(v.Type(), qualifier),
+			Offset: offsets[i],
+			Size:   sizes.Sizeof(v.Type()),
+			Align:  sizes.Alignof(v.Type()),
+		}
+	}
+	return newStructLayout(name, sizes.Sizeof(st), sizes.Alignof(st), fields)
+}
+
+// runLayout handles `analyzer layout [--arch <arch>] [--padded] <package-dir> [type...]`
+func runLayout(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("layout", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	arch := flags.String("arch", runtime.GOARCH, "architecture whose sizes to use")
+	padded := flags.Bool("padded", false, "only show structs that could be smaller")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if flags.NArg() < 1 {
+		return fmt.Errorf("%w: expected a package directory", errUsage)
+	}
+	if info, err := os.Stat(flags.Arg(0)); err != nil {
+		return err
+	} else if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", flags.Arg(0))
+	}
+
+	layouts, err := PackageLayouts(flags.Arg(0), *arch)
+	if err != nil {
+		return err
+	}
+
+	names := flags.Args()[1:]
+	wanted := make(map[string]bool)
+	for _, name := range names {
+		wanted[name] = true
+	}
+	found := make(map[string]bool)
+	printed := 0
+	for _, l := range layouts {
+		if len(wanted) > 0 && !wanted[l.Name] || *padded && l.Suggested == nil {
+			continue
+		}
+		if printed > 0 {
+			fmt.Fprintln(stdout)
+		}
+		fmt.Fprint(stdout, l)
+		found[l.Name] = true
+		printed++
+	}
+	for _, name := range names {
+		if !found[name] && !*padded {
+			return fmt.Errorf("no struct type %s in %s (generic types are skipped)", name, flags.Arg(0))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+/*----- Extension: Struct Layout Inspector -----*/
+
+type layoutPadded struct {
+	A bool
+	B int64
+	C bool
+	D int32
+}
+
+type layoutTight struct {
+	B int64
+	D int32
+	A bool
+	C bool
+}
+
+type layoutTrailingEmpty struct {
+	N int32
+	E struct{}
+}
+
+// 1. InspectLayout
+func TestInspectLayout(t *testing.T) {
+	var p layoutPadded
+	tests := []struct {
+		name          string
+		value         any
+		wantSize      int64
+		wantPadding   int64
+		wantOffsets   []int64
+		wantSuggested []string
+		wantNewSize   int64
+	}{
+		{
+			name:          "padded",
+			value:         p,
+			wantSize:      int64(unsafe.Sizeof(p)),
+			wantPadding:   int64(unsafe.Sizeof(p)) - 14,
+			wantOffsets:   []int64{int64(unsafe.Offsetof(p.A)), int64(unsafe.Offsetof(p.B)), int64(unsafe.Offsetof(p.C)), int64(unsafe.Offsetof(p.D))},
+			wantSuggested: []string{"B", "D", "A", "C"},
+			wantNewSize:   16,
+		},
+		{
+			name:        "already tight, through a pointer",
+			value:       &layoutTight{},
+			wantSize:    16,
+			wantPadding: 2,
+			wantOffsets: []int64{0, 8, 12, 13},
+		},
+		{
+			name:          "trailing zero-sized field",
+			value:         layoutTrailingEmpty{},
+			wantSize:      int64(unsafe.Sizeof(layoutTrailingEmpty{})),
+			wantPadding:   int64(unsafe.Sizeof(layoutTrailingEmpty{})) - 4,
+			wantOffsets:   []int64{0, 4},
+			wantSuggested: []string{"E", "N"},
+			wantNewSize:   4,
+		},
+		{
+			name:        "empty struct",
+			value:       struct{}{},
+			wantSize:    0,
+			wantOffsets: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := InspectLayout(tt.value)
+			if err != nil {
+				t.Fatalf("InspectLayout() error = %v", err)
+			}
+			var offsets []int64
+			for _, f := range l.Fields {
+				offsets = append(offsets, f.Offset)
+			}
+			if l.Size != tt.wantSize || l.Padding != tt.wantPadding || !slices.Equal(offsets, tt.wantOffsets) {
+				t.Errorf("InspectLayout() size %d padding %d offsets %v, want %d %d %v", l.Size, l.Padding, offsets, tt.wantSize, tt.wantPadding, tt.wantOffsets)
+			}
+			if !slices.Equal(l.Suggested, tt.wantSuggested) || l.SuggestedSize != tt.wantNewSize {
+				t.Errorf("InspectLayout() suggests %v (size %d), want %v (size %d)", l.Suggested, l.SuggestedSize, tt.wantSuggested, tt.wantNewSize)
+			}
+		})
+	}
+
+	for _, v := range []any{nil, 42, []int{1}, new(int)} {
+		if _, err := InspectLayout(v); err == nil {
+			t.Errorf("InspectLayout(%T) succeeded, want an error", v)
+		}
+	}
+}
+
+// simulateSize must agree with the compiler for the order fields are in
+func TestSimulateSize(t *testing.T) {
+	for _, v := range []any{layoutPadded{}, layoutTight{}, layoutTrailingEmpty{}, ProcessInfo{}, ProcessNode{}, MemoryRegion{}, demoRecord{}, copyDemo{}} {
+		l, err := InspectLayout(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := simulateSize(l.Fields, l.Align); got != l.Size {
+			t.Errorf("simulateSize(%s) = %d, compiler says %d", l.Name, got, l.Size)
+		}
+	}
+}
+
+// 2. PackageLayouts
+func TestPackageLayouts(t *testing.T) {
+	tests := []struct {
+		arch        string
+		name        string
+		wantSize    int64
+		wantNewSize int64 // 0 when no better order exists
+	}{
+		{arch: "amd64", name: "Padded", wantSize: 24, wantNewSize: 16},
+		{arch: "amd64", name: "Tight", wantSize: 16},
+		{arch: "amd64", name: "TrailingEmpty", wantSize: 8, wantNewSize: 4},
+		{arch: "amd64", name: "Event", wantSize: 40, wantNewSize: 32},
+		{arch: "386", name: "Padded", wantSize: 20, wantNewSize: 16}, // int64 is 4-byte aligned
+		{arch: "386", name: "Tight", wantSize: 16},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.arch+"/"+tt.name, func(t *testing.T) {
+			layouts, err := PackageLayouts("testdata/layout/demo", tt.arch)
+			if err != nil {
+				t.Fatalf("PackageLayouts() error = %v", err)
+			}
+			for _, l := range layouts {
+				if l.Name == "NotAStruct" || l.Name == "Box" {
+					t.Errorf("PackageLayouts() reported non-struct or generic type %s", l.Name)
+				}
+				if l.Name == tt.name {
+					if l.Size != tt.wantSize || l.SuggestedSize != tt.wantNewSize {
+						t.Errorf("%s size %d suggested %d, want %d and %d", l.Name, l.Size, l.SuggestedSize, tt.wantSize, tt.wantNewSize)
+					}
+					return
+				}
+			}
+			t.Errorf("PackageLayouts() has no struct %s", tt.name)
+		})
+	}
+
+	if _, err := PackageLayouts("testdata/layout/demo", "z80"); err == nil {
+		t.Error("PackageLayouts() with an unknown architecture succeeded")
+	}
+
+	t.Run("syntax errors from every file", func(t *testing.T) {
+		dir := t.TempDir()
+		for name, src := range map[string]string{
+			"a.go": "package broken\n\ntype A struct {\n",
+			"b.go": "package broken\n\nfunc B( {}\n",
+		} {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		_, err := PackageLayouts(dir, "amd64")
+		if err == nil || !strings.Contains(err.Error(), "a.go") || !strings.Contains(err.Error(), "b.go") {
+			t.Errorf("PackageLayouts() error = %v, want syntax errors from a.go and b.go", err)
+		}
+	})
+}
+
+func TestRunLayout(t *testing.T) {
+	var stdout strings.Builder
+	if err := runLayout([]string{"--arch", "amd64", "--padded", "testdata/layout/demo"}, &stdout); err != nil {
+		t.Fatalf("runLayout() error = %v", err)
+	}
+	out := stdout.String()
+	for _, want := range []string{"Padded: size 24, align 8, padding 10", "suggested order: B, D, A, C (size 16, saves 8 bytes)", "Event:", "At      time.Time"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("runLayout() output is missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Tight:") {
+		t.Errorf("runLayout(--padded) printed a tight struct:\n%s", out)
+	}
+
+	stdout.Reset()
+	if err := runLayout([]string{"testdata/layout/demo", "Tight"}, &stdout); err != nil || !strings.HasPrefix(stdout.String(), "Tight:") || strings.Contains(stdout.String(), "Padded") {
+		t.Errorf("runLayout(Tight) = %q, %v; want only Tight", stdout.String(), err)
+	}
+
+	errorTests := []struct {
+		name      string
+		args      []string
+		wantUsage bool
+	}{
+		{name: "no directory", args: nil, wantUsage: true},
+		{name: "bad flag", args: []string{"--wide", "."}, wantUsage: true},
+		{name: "missing directory", args: []string{"testdata/layout/missing"}},
+		{name: "unknown type", args: []string{"testdata/layout/demo", "Missing"}},
+		{name: "generic type", args: []string{"testdata/layout/demo", "Box"}},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runLayout(tt.args, &stdout)
+			if err == nil || errors.Is(err, errUsage) != tt.wantUsage {
+				t.Errorf("runLayout() error = %v, want usage error %v", err, tt.wantUsage)
+			}
+		})
+	}
+}
@@ -3,8 +3,13 @@ package main
 import (
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"os"
+	"reflect"
+	"strings"
+	"time"
+	"unsafe"
 )
 
 /*----- Part 1: Table-Driven Tests & Math Operations -----*/
@@ -110,7 +115,11 @@ func Apply(nums []int, operation func(int) int) []int {
 
 // 2. Filter - returns elements where predicate is true
 func Filter(nums []int, predicate func(int) bool) []int {
-	result := []int{}
+	// Sized for the worst case so the result is allocated only once. The
+	// tradeoff: the result keeps capacity for len(nums) ints even when few
+	// match, so callers holding a small result of a large input for long
+	// should keep slices.Clone(result) instead.
+	result := make([]int, 0, len(nums))
 	for _, num := range nums {
 		if predicate(num) {
 			result = append(result, num)
@@ -138,27 +147,9 @@ func Compose(f func(int) int, g func(int) int) func(int) int {
 /*----- Part 4: Process Explorer -----*/
 
 // ExploreProcess demonstrates process information and memory addresses
-func ExploreProcess() {
-	// Get current process
-	pid := os.Getpid()
-	ppid := os.Getppid()
-
-	// Create a slice of integers
-	data := []int{1, 2, 3, 4, 5}
-
-	// Get memory addresses
-	// &data gets the address of the slice header
-	// &data[0] gets the address of the first element in the underlying array
-	sliceAddr := &data
-	firstElemAddr := &data[0]
-
-	// Print process information
-	println("=== Process Information ===")
-	println("Current Process ID:", pid)
-	println("Parent Process ID:", ppid)
-	println("Memory address of slice:", sliceAddr)
-	println("Memory address of first element:", firstElemAddr)
-	println("Note: Other processes cannot access these memory addresses due to process isolation")
+// It pretty-prints GetProcessInfo (see procfs.go) for the current process to w.
+func ExploreProcess(w io.Writer) {
+	writeDemoText(w, processRecords())
 
 	/*
 		Include comments explaining:
@@ -174,6 +165,71 @@ func ExploreProcess() {
 	*/
 }
 
+// processRecords gathers what ExploreProcess prints, one record per fact
+func processRecords() []demoRecord {
+	var section demoBuilder
+
+	// Get current process
+	info, err := GetProcessInfo()
+	if err != nil {
+		// /proc only exists on Linux; fall back to what the os package knows
+		info = ProcessInfo{PID: os.Getpid(), PPID: os.Getppid()}
+	}
+
+	// Create a slice of integers
+	data := []int{1, 2, 3, 4, 5}
+
+	// Get memory addresses
+	// &data gets the address of the slice header
+	// &data[0] gets the address of the first element in the underlying array
+	sliceAddr := &data
+	firstElemAddr := &data[0]
+
+	// Process information
+	section.say("=== Process Information ===")
+	section.add(record("process", "pid", info.PID, nil), "Current Process ID:", info.PID)
+	section.add(record("process", "ppid", info.PPID, nil), "Parent Process ID:", info.PPID)
+	if err == nil {
+		startTime := info.StartTime.Format(time.RFC3339)
+		section.add(record("process", "uid/gid", []int{info.UID, info.GID}, nil), "User ID / Group ID:", info.UID, "/", info.GID)
+		section.add(record("process", "executable", info.Executable, nil), "Executable:", info.Executable)
+		section.add(record("process", "working directory", info.Cwd, nil), "Working directory:", info.Cwd)
+		section.add(record("process", "command line", info.CommandLine, nil), "Command line:", strings.Join(info.CommandLine, " "))
+		section.add(record("process", "environment variables", info.EnvCount, nil), "Environment variables:", info.EnvCount)
+		section.add(record("process", "start time", startTime, nil), "Started at:", startTime)
+		section.add(record("process", "threads", info.Threads, nil), "Threads:", info.Threads)
+		section.add(record("process", "resident memory kB", info.RSSBytes/1024, nil), "Resident memory (RSS):", info.RSSBytes/1024, "kB")
+		section.add(record("process", "virtual memory kB", info.VSZBytes/1024, nil), "Virtual memory (VSZ):", info.VSZBytes/1024, "kB")
+	}
+	sliceHeader, firstElem := fmt.Sprintf("%p", sliceAddr), fmt.Sprintf("%p", firstElemAddr)
+	section.add(record("process", "slice header address", sliceHeader, nil), "Memory address of slice:", sliceHeader)
+	section.add(record("process", "first element address", firstElem, nil), "Memory address of first element:", firstElem)
+
+	// Locate both addresses in our own memory map (see memmap.go)
+	if memoryMap, err := ReadMemoryMap(); err == nil {
+		regions := []struct {
+			name string
+			addr uintptr
+		}{
+			{name: "slice header", addr: uintptr(unsafe.Pointer(sliceAddr))},
+			{name: "first element", addr: uintptr(unsafe.Pointer(firstElemAddr))},
+			{name: "ExploreProcess code", addr: reflect.ValueOf(ExploreProcess).Pointer()},
+		}
+		for _, region := range regions {
+			where := memoryMap.Annotate(region.addr)
+			section.add(record("process", region.name+" region", where, nil), "  "+region.name+":", where)
+		}
+	}
+
+	// How the struct above is laid out in memory (see layout.go)
+	if layout, err := InspectLayout(info); err == nil {
+		summary := fmt.Sprint(layout.Size, " bytes, ", len(layout.Fields), " fields, ", layout.Padding, " bytes of padding")
+		section.add(record("process", "ProcessInfo layout", summary, nil), "ProcessInfo struct:", summary)
+	}
+	section.say("Note: Other processes cannot access these memory addresses due to process isolation")
+	return section.done()
+}
+
 /*----- Part 5: Pointer Playground & Escape Analysis -----*/
 
 // 1. DoubleValue takes an integer and doubles it
@@ -251,6 +307,8 @@ func AnalyzeEscape() {
 
 		3. What does "escapes to heap" mean?
 		   "Escape to heap" means a variable is allocated in heap memory instead of stack memory.
+
+		Run `analyzer escape .` to see the compiler's own verdict for every variable.
 	*/
 }
 
@@ -319,34 +377,25 @@ func TryAll(operations []func() error) []error {
 	return errors
 }
 
-func main() {
-	// Call the Part 4 function
-	ExploreProcess()
-
-	/*----- Part 6: Integration - Main Program -----*/
+/*----- Part 6: Integration - Main Program -----*/
 
-	println("\n=== Math Operations ===")
+// mathRecords runs the Math Operations section of the demo
+func mathRecords() []demoRecord {
+	var section demoBuilder
+	section.say("\n=== Math Operations ===")
 
 	// Factorial demo
 	facts := []int{0, 5, 10}
 	for _, n := range facts {
 		result, err := Factorial(n)
-		if err != nil {
-			println("Factorial(", n, ") error:", err.Error())
-		} else {
-			println("Factorial(", n, ") =", result)
-		}
+		section.addResult(record("math", "Factorial", result, err, n), "Factorial(", n, ")")
 	}
 
 	// IsPrime demo
 	primes := []int{17, 20, 25}
 	for _, n := range primes {
 		result, err := IsPrime(n)
-		if err != nil {
-			println("IsPrime(", n, ") error:", err.Error())
-		} else {
-			println("IsPrime(", n, ") =", result)
-		}
+		section.addResult(record("math", "IsPrime", result, err, n), "IsPrime(", n, ")")
 	}
 
 	// Power demo
@@ -359,74 +408,85 @@ func main() {
 
 	for _, test := range powerTests {
 		result, err := Power(test.base, test.exponent)
-		if err != nil {
-			println("Power(", test.base, "^", test.exponent, ") error:", err.Error())
-		} else {
-			println("Power(", test.base, "^", test.exponent, ") =", result)
-		}
+		section.addResult(record("math", "Power", result, err, test.base, test.exponent), "Power(", test.base, "^", test.exponent, ")")
 	}
+	return section.done()
+}
 
-	println("\n=== Closure Demonstration ===")
+// closureRecords runs the Closure Demonstration section of the demo
+func closureRecords() []demoRecord {
+	var section demoBuilder
+	section.say("\n=== Closure Demonstration ===")
 
 	// Counter demo
 	counter1 := MakeCounter(0)
-	println("Counter1 starting at 0:")
-	println("Counter1:", counter1())
-	println("Counter1:", counter1())
-	println("Counter1:", counter1())
+	section.say("Counter1 starting at 0:")
+	for i := 0; i < 3; i++ {
+		count := counter1()
+		section.add(record("closures", "counter1", count, nil), "Counter1:", count)
+	}
 
 	counter2 := MakeCounter(100)
-	println("\nCounter2 starting at 100:")
-	println("Counter2:", counter2())
-	println("Counter2:", counter2())
+	section.say("\nCounter2 starting at 100:")
+	for i := 0; i < 2; i++ {
+		count := counter2()
+		section.add(record("closures", "counter2", count, nil), "Counter2:", count)
+	}
 
-	println("\nBack to Counter1 (showing independence):")
-	println("Counter1:", counter1())
+	section.say("\nBack to Counter1 (showing independence):")
+	count := counter1()
+	section.add(record("closures", "counter1", count, nil), "Counter1:", count)
 
 	// Multiplier demo
 	doubler := MakeMultiplier(2)
 	tripler := MakeMultiplier(3)
 	testNumber := 7
 
-	println("\nMultiplier functions on number", testNumber, ":")
-	println("Doubler:", testNumber, "->", doubler(testNumber))
-	println("Tripler:", testNumber, "->", tripler(testNumber))
+	section.say("\nMultiplier functions on number", testNumber, ":")
+	doubled, tripled := doubler(testNumber), tripler(testNumber)
+	section.add(record("closures", "doubler", doubled, nil, testNumber), "Doubler:", testNumber, "->", doubled)
+	section.add(record("closures", "tripler", tripled, nil, testNumber), "Tripler:", testNumber, "->", tripled)
 
 	// Accumulator demo
 	add, subtract, get := MakeAccumulator(50)
-	println("\nAccumulator starting at 50:")
+	section.say("\nAccumulator starting at 50:")
 	add(25)
-	println("After adding 25:", get())
+	section.add(record("closures", "accumulator add", get(), nil, 25), "After adding 25:", get())
 	subtract(15)
-	println("After subtracting 15:", get())
+	section.add(record("closures", "accumulator subtract", get(), nil, 15), "After subtracting 15:", get())
 	add(40)
-	println("After adding 40:", get())
+	section.add(record("closures", "accumulator add", get(), nil, 40), "After adding 40:", get())
+	return section.done()
+}
 
-	println("\n=== Higher-Order Functions ===")
+// higherOrderRecords runs the Higher-Order Functions section of the demo
+func higherOrderRecords() []demoRecord {
+	var section demoBuilder
+	section.say("\n=== Higher-Order Functions ===")
 
 	// Create the slice
 	numbers := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
-	println("Original slice:", fmt.Sprint(numbers))
+	section.say("Original slice:", numbers)
 
 	// Apply - square all numbers
 	squared := Apply(numbers, func(x int) int { return x * x })
-	println("Squared:", fmt.Sprint(squared))
+	section.add(record("higher-order", "Apply square", squared, nil, numbers), "Squared:", squared)
 
 	// Filter - get even numbers
 	evens := Filter(numbers, func(x int) bool { return x%2 == 0 })
-	println("Even numbers:", fmt.Sprint(evens))
+	section.add(record("higher-order", "Filter even", evens, nil, numbers), "Even numbers:", evens)
 
 	// Filter - get numbers greater than 5
 	greaterThan5 := Filter(numbers, func(x int) bool { return x > 5 })
-	println("Numbers > 5:", fmt.Sprint(greaterThan5))
+	section.add(record("higher-order", "Filter > 5", greaterThan5, nil, numbers), "Numbers > 5:", greaterThan5)
 
 	// Reduce - sum all numbers
 	sum := Reduce(numbers, 0, func(acc, curr int) int { return acc + curr })
-	println("Sum of all numbers:", sum)
+	section.add(record("higher-order", "Reduce sum", sum, nil, numbers), "Sum of all numbers:", sum)
 
 	// Reduce - product of all numbers
 	product := Reduce(numbers, 1, func(acc, curr int) int { return acc * curr })
-	println("Product of all numbers:", product)
+	section.add(record("higher-order", "Reduce product", product, nil, numbers), "Product of all numbers:", product)
 
 	// Compose - create function that doubles then adds 10
 	doubleThenAdd10 := Compose(
@@ -436,41 +496,62 @@ func main() {
 
 	testValue := 6
 	composedResult := doubleThenAdd10(testValue)
-	println("\nCompose: double then add 10")
-	println("doubleThenAdd10(", testValue, ") =", composedResult, "(expected: (6*2)+10 = 22)")
+	section.say("\nCompose: double then add 10")
+	section.add(record("higher-order", "Compose double then add 10", composedResult, nil, testValue),
+		"doubleThenAdd10(", testValue, ") =", composedResult, "(expected: (6*2)+10 = 22)")
+	return section.done()
+}
 
-	println("\n=== Pointer Demonstration ===")
+// pointerRecords runs the Pointer Demonstration section of the demo
+func pointerRecords() []demoRecord {
+	var section demoBuilder
+	section.say("\n=== Pointer Demonstration ===")
 
 	// SwapValues demo
 	a, b := 5, 10
-	println("Before SwapValues: a =", a, ", b =", b)
+	section.say("Before SwapValues: a =", a, ", b =", b)
 	newA, newB := SwapValues(a, b)
-	println("After SwapValues: a =", a, ", b =", b, "(originals unchanged)")
-	println("Returned values: newA =", newA, ", newB =", newB)
+	section.say("After SwapValues: a =", a, ", b =", b, "(originals unchanged)")
+	section.add(record("pointers", "SwapValues", []int{newA, newB}, nil, a, b), "Returned values: newA =", newA, ", newB =", newB)
 
 	// SwapPointers demo
 	c, d := 15, 25
-	println("\nBefore SwapPointers: c =", c, ", d =", d)
+	inputs := []any{c, d}
+	section.say("\nBefore SwapPointers: c =", c, ", d =", d)
 	SwapPointers(&c, &d)
-	println("After SwapPointers: c =", c, ", d =", d, "(originals modified)")
+	section.add(record("pointers", "SwapPointers", []int{c, d}, nil, inputs...), "After SwapPointers: c =", c, ", d =", d, "(originals modified)")
 
 	// DoubleValue vs DoublePointer demo
-	println("\nDoubleValue vs DoublePointer:")
+	section.say("\nDoubleValue vs DoublePointer:")
 	x := 7
-	println("Original x =", x)
+	original := x
+	section.say("Original x =", x)
 
 	DoubleValue(x)
-	println("After DoubleValue(x): x =", x, "(unchanged - pass by value)")
+	section.add(record("pointers", "DoubleValue", x, nil, original), "After DoubleValue(x): x =", x, "(unchanged - pass by value)")
 
 	DoublePointer(&x)
-	println("After DoublePointer(&x): x =", x, "(changed - pass by reference)")
+	section.add(record("pointers", "DoublePointer", x, nil, original), "After DoublePointer(&x): x =", x, "(changed - pass by reference)")
 
 	// CreateOnStack vs CreateOnHeap demo
-	println("\nStack vs Heap allocation:")
+	section.say("\nStack vs Heap allocation:")
 	stackVal := CreateOnStack()
 	heapPtr := CreateOnHeap()
-	println("CreateOnStack(): returns value", stackVal)
-	println("CreateOnHeap(): returns pointer, dereferenced value =", *heapPtr)
+	section.add(record("pointers", "CreateOnStack", stackVal, nil), "CreateOnStack(): returns value", stackVal)
+	section.add(record("pointers", "CreateOnHeap", *heapPtr, nil), "CreateOnHeap(): returns pointer, dereferenced value =", *heapPtr)
+	return section.done()
+}
+
+func main() {
+	// Subcommands turn the binary into a calculator (see cli.go)
+	if len(os.Args) > 1 {
+		os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+	}
 
-	println("\n=== Program Complete ===")
+	// Each section prints its records as text; demoSections in report.go
+	// lists them, and --format renders the same records as data
+	for _, section := range demoSections {
+		writeDemoText(os.Stdout, section.records())
+	}
+	fmt.Println("\n=== Program Complete ===")
 }
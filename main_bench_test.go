@@ -0,0 +1,286 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+/*----- Benchmarks -----*/
+
+// benchSizes are the slice lengths used by the higher-order benchmarks
+var benchSizes = []int{10, 1_000, 100_000}
+
+// benchSink keeps factory results reachable so their closures are really
+// allocated instead of being inlined away
+var benchSink any
+
+// benchInput returns 1..n
+func benchInput(n int) []int {
+	nums := make([]int, n)
+	for i := range nums {
+		nums[i] = i + 1
+	}
+	return nums
+}
+
+/*----- Part 1: Math Operations -----*/
+
+func BenchmarkFactorial(b *testing.B) {
+	for _, n := range []int{0, 10, 20} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				Factorial(n)
+			}
+		})
+	}
+}
+
+func BenchmarkIsPrime(b *testing.B) {
+	tests := []struct {
+		name string
+		n    int64
+	}{
+		{name: "even", n: 1_000_000},
+		{name: "prime=97", n: 97},
+		{name: "prime=7919", n: 7919},
+		{name: "prime=1000003", n: 1_000_003},
+		{name: "prime=2^31-1", n: 2_147_483_647},
+		{name: "prime=999999999989", n: 999_999_999_989},
+	}
+
+	for _, tt := range tests {
+		b.Run(tt.name, func(b *testing.B) {
+			if is64BitOnly(tt.n) {
+				b.Skipf("%d does not fit in a %d-bit int", tt.n, strconv.IntSize)
+			}
+			b.ReportAllocs()
+			for b.Loop() {
+				IsPrime(int(tt.n))
+			}
+		})
+	}
+}
+
+func BenchmarkPower(b *testing.B) {
+	for _, exponent := range []int{0, 10, 62} {
+		b.Run(fmt.Sprintf("2^%d", exponent), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				Power(2, exponent)
+			}
+		})
+	}
+}
+
+/*----- Part 2: Function Factory & Closures -----*/
+
+func BenchmarkClosures(b *testing.B) {
+	b.Run("MakeCounter", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			benchSink = MakeCounter(0)
+		}
+	})
+
+	b.Run("counter call", func(b *testing.B) {
+		counter := MakeCounter(0)
+		b.ReportAllocs()
+		for b.Loop() {
+			counter()
+		}
+	})
+
+	b.Run("MakeMultiplier", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			benchSink = MakeMultiplier(3)
+		}
+	})
+
+	b.Run("MakeAccumulator", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			add, subtract, get := MakeAccumulator(100)
+			add(5)
+			subtract(3)
+			benchSink = get
+		}
+	})
+}
+
+/*----- Part 3: Higher-Order Functions -----*/
+
+func BenchmarkApply(b *testing.B) {
+	for _, size := range benchSizes {
+		nums := benchInput(size)
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				Apply(nums, func(x int) int { return x * x })
+			}
+		})
+	}
+}
+
+func BenchmarkFilter(b *testing.B) {
+	for _, size := range benchSizes {
+		nums := benchInput(size)
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				Filter(nums, func(x int) bool { return x%2 == 0 })
+			}
+		})
+	}
+}
+
+func BenchmarkReduce(b *testing.B) {
+	for _, size := range benchSizes {
+		nums := benchInput(size)
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				Reduce(nums, 0, func(acc, curr int) int { return acc + curr })
+			}
+		})
+	}
+}
+
+func BenchmarkCompose(b *testing.B) {
+	addTen := func(x int) int { return x + 10 }
+	double := func(x int) int { return x * 2 }
+
+	b.Run("build", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			benchSink = Compose(addTen, double)
+		}
+	})
+
+	b.Run("call", func(b *testing.B) {
+		composed := Compose(addTen, double)
+		b.ReportAllocs()
+		for b.Loop() {
+			composed(5)
+		}
+	})
+}
+
+/*----- Part 5: Pointer Playground & Escape Analysis -----*/
+
+func BenchmarkPointers(b *testing.B) {
+	b.Run("DoubleValue", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			DoubleValue(21)
+		}
+	})
+
+	b.Run("DoublePointer", func(b *testing.B) {
+		x := 1
+		b.ReportAllocs()
+		for b.Loop() {
+			x = 1
+			DoublePointer(&x)
+		}
+	})
+
+	b.Run("SwapValues", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			SwapValues(1, 2)
+		}
+	})
+
+	b.Run("SwapPointers", func(b *testing.B) {
+		x, y := 1, 2
+		b.ReportAllocs()
+		for b.Loop() {
+			SwapPointers(&x, &y)
+		}
+	})
+
+	b.Run("CreateOnStack", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			testSinkInt = CreateOnStack()
+		}
+	})
+
+	b.Run("CreateOnHeap", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			testSinkPtr = CreateOnHeap()
+		}
+	})
+}
+
+/*----- Bonus Challenges -----*/
+
+func BenchmarkMemoizedFactorial(b *testing.B) {
+	for _, n := range []int{10, 20} {
+		b.Run(fmt.Sprintf("plain/n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				Factorial(n)
+			}
+		})
+
+		b.Run(fmt.Sprintf("memoized/n=%d", n), func(b *testing.B) {
+			factorial := MakeMemoizedFactorial()
+			factorial(n) // warm the cache so every timed call is a hit
+			b.ReportAllocs()
+			for b.Loop() {
+				factorial(n)
+			}
+		})
+
+		b.Run(fmt.Sprintf("memoized-cold/n=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				factorial := MakeMemoizedFactorial()
+				factorial(n)
+				benchSink = factorial
+			}
+		})
+	}
+}
+
+func BenchmarkPipeline(b *testing.B) {
+	addOne := func(x int) int { return x + 1 }
+
+	for _, stages := range []int{1, 10, 100} {
+		operations := make([]func(int) int, stages)
+		for i := range operations {
+			operations[i] = addOne
+		}
+
+		for _, size := range benchSizes[:2] {
+			nums := benchInput(size)
+			b.Run(fmt.Sprintf("stages=%d/size=%d", stages, size), func(b *testing.B) {
+				b.ReportAllocs()
+				for b.Loop() {
+					Pipeline(nums, operations...)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkTryAll(b *testing.B) {
+	errFailed := errors.New("failed")
+	operations := []func() error{
+		func() error { return nil },
+		func() error { return errFailed },
+		func() error { return nil },
+		func() error { return errFailed },
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		TryAll(operations)
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*----- Extension: Memory Map Inspection -----*/
+
+// RegionKind classifies what a mapped memory region holds
+type RegionKind string
+
+const (
+	RegionHeap    RegionKind = "heap"    // the C brk heap, [heap]
+	RegionStack   RegionKind = "stack"   // the main thread's OS stack, [stack]
+	RegionAnon    RegionKind = "anon"    // anonymous memory, where Go keeps its heap and goroutine stacks
+	RegionText    RegionKind = "text"    // the executable's machine code
+	RegionData    RegionKind = "data"    // the executable's read-only and writable data
+	RegionLibrary RegionKind = "library" // a shared library
+	RegionFile    RegionKind = "file"    // any other mapped file
+	RegionKernel  RegionKind = "kernel"  // kernel-provided pages such as [vdso]
+)
+
+// MemoryRegion is one line of /proc/<pid>/maps plus its smaps statistics
+type MemoryRegion struct {
+	Start    uintptr
+	End      uintptr // exclusive
+	Perms    string  // e.g. "r-xp": read, write, execute, private/shared
+	Offset   uint64
+	Device   string
+	Inode    uint64
+	Path     string
+	Kind     RegionKind
+	RSSBytes uint64 // resident in RAM; only known when read from smaps
+	PSSBytes uint64 // RSS with shared pages divided among their users
+}
+
+// Contains reports whether addr falls inside the region
+func (r MemoryRegion) Contains(addr uintptr) bool {
+	return r.Start <= addr && addr < r.End
+}
+
+// MemoryMap is the address space of a process, sorted by start address
+type MemoryMap []MemoryRegion
+
+// ReadMemoryMap reads the current process's memory map
+func ReadMemoryMap() (MemoryMap, error) {
+	return procFS{root: "/proc"}.memoryMap("self")
+}
+
+// memoryMap reads smaps for pid, falling back to maps (which lacks the
+// RSS figures) when smaps is unavailable
+func (fs procFS) memoryMap(pid string) (MemoryMap, error) {
+	f, err := os.Open(fs.path(pid, "smaps"))
+	if err != nil {
+		if f, err = os.Open(fs.path(pid, "maps")); err != nil {
+			return nil, err
+		}
+	}
+	defer f.Close()
+
+	exe, _ := os.Readlink(fs.path(pid, "exe"))
+	return parseMemoryMap(f, exe)
+}
+
+// parseMemoryMap parses maps or smaps content
+// Region header lines look like
+//
+//	55faaa0a4000-55faaa0a6000 r--p 00000000 fe:00 681885   /usr/bin/head
+//
+// and in smaps are followed by "Key:  value kB" lines describing them.
+func parseMemoryMap(r io.Reader, exe string) (MemoryMap, error) {
+	var regions MemoryMap
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		// smaps statistics belong to the most recent region
+		if key, ok := strings.CutSuffix(fields[0], ":"); ok {
+			if len(regions) > 0 && len(fields) >= 2 {
+				current := &regions[len(regions)-1]
+				value, _ := strconv.ParseUint(fields[1], 10, 64)
+				switch key {
+				case "Rss":
+					current.RSSBytes = value * 1024
+				case "Pss":
+					current.PSSBytes = value * 1024
+				}
+			}
+			continue
+		}
+
+		region, err := parseRegion(line, fields, exe)
+		if err != nil {
+			return nil, err
+		}
+		regions = append(regions, region)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(regions, func(i, j int) bool { return regions[i].Start < regions[j].Start })
+	return regions, nil
+}
+
+// parseRegion parses a single region header line
+func parseRegion(line string, fields []string, exe string) (MemoryRegion, error) {
+	if len(fields) < 5 {
+		return MemoryRegion{}, fmt.Errorf("malformed maps line %q", line)
+	}
+
+	var region MemoryRegion
+	start, end, ok := strings.Cut(fields[0], "-")
+	startAddr, err1 := strconv.ParseUint(start, 16, 64)
+	endAddr, err2 := strconv.ParseUint(end, 16, 64)
+	offset, err3 := strconv.ParseUint(fields[2], 16, 64)
+	inode, err4 := strconv.ParseUint(fields[4], 10, 64)
+	if !ok || err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+		return MemoryRegion{}, fmt.Errorf("malformed maps line %q", line)
+	}
+	region.Start, region.End = uintptr(startAddr), uintptr(endAddr)
+	region.Perms, region.Offset, region.Device, region.Inode = fields[1], offset, fields[3], inode
+
+	// The path is everything after the inode and may contain spaces
+	if len(fields) > 5 {
+		rest := line
+		for _, f := range fields[:5] {
+			rest = strings.TrimLeft(rest, " \t")
+			rest = rest[len(f):]
+		}
+		region.Path = strings.TrimSpace(rest)
+	}
+	region.Kind = classifyRegion(region, exe)
+	return region, nil
+}
+
+// classifyRegion decides the RegionKind from the path and permissions
+func classifyRegion(r MemoryRegion, exe string) RegionKind {
+	switch {
+	case r.Path == "":
+		return RegionAnon
+	case r.Path == "[heap]":
+		return RegionHeap
+	case r.Path == "[stack]" || strings.HasPrefix(r.Path, "[stack:"):
+		return RegionStack
+	case strings.HasPrefix(r.Path, "["):
+		return RegionKernel
+	case exe != "" && r.Path == exe:
+		if strings.Contains(r.Perms, "x") {
+			return RegionText
+		}
+		return RegionData
+	case strings.HasSuffix(r.Path, ".so") || strings.Contains(r.Path, ".so."):
+		return RegionLibrary
+	}
+	return RegionFile
+}
+
+// Find returns the region containing addr
+func (m MemoryMap) Find(addr uintptr) (MemoryRegion, bool) {
+	i := sort.Search(len(m), func(i int) bool { return m[i].End > addr })
+	if i < len(m) && m[i].Contains(addr) {
+		return m[i], true
+	}
+	return MemoryRegion{}, false
+}
+
+// Annotate describes where addr lives, e.g.
+// "0xc000012345 is in anon rw-p 0xc000000000-0xc000400000 (4096 kB)"
+func (m MemoryMap) Annotate(addr uintptr) string {
+	region, ok := m.Find(addr)
+	if !ok {
+		return fmt.Sprintf("%#x is not mapped", addr)
+	}
+
+	where := string(region.Kind)
+	if region.Path != "" && region.Kind != RegionHeap && region.Kind != RegionStack {
+		where += " " + region.Path
+	}
+	return fmt.Sprintf("%#x is in %s %s %#x-%#x (%d kB)", addr, where, region.Perms, region.Start, region.End, (region.End-region.Start)/1024)
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+/*----- Extension: Memory Map Inspection -----*/
+
+// TestParseMemoryMap checks region parsing and classification from maps
+func TestParseMemoryMap(t *testing.T) {
+	skipOn32Bit(t)
+	f, err := os.Open("testdata/proc/4242/maps")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	m, err := parseMemoryMap(f, "/usr/bin/app")
+	if err != nil {
+		t.Fatalf("parseMemoryMap() error = %v", err)
+	}
+
+	want := []struct {
+		start uint64
+		kind  RegionKind
+		path  string
+	}{
+		{start: 0x400000, kind: RegionText, path: "/usr/bin/app"},
+		{start: 0x452000, kind: RegionData, path: "/usr/bin/app"},
+		{start: 0x460000, kind: RegionData, path: "/usr/bin/app"},
+		{start: 0x1a2b000, kind: RegionHeap, path: "[heap]"},
+		{start: 0xc000000000, kind: RegionAnon, path: ""},
+		{start: 0x7f10a0000000, kind: RegionLibrary, path: "/usr/lib/libc.so.6"},
+		{start: 0x7f10a0100000, kind: RegionFile, path: "/srv/app/data file.bin"},
+		{start: 0x7ffd5e1f0000, kind: RegionStack, path: "[stack]"},
+		{start: 0x7ffd5e3f8000, kind: RegionKernel, path: "[vdso]"},
+	}
+	if len(m) != len(want) {
+		t.Fatalf("parseMemoryMap() found %d regions, want %d", len(m), len(want))
+	}
+	for i, w := range want {
+		if uint64(m[i].Start) != w.start || m[i].Kind != w.kind || m[i].Path != w.path {
+			t.Errorf("region %d = %#x %s %q, want %#x %s %q", i, m[i].Start, m[i].Kind, m[i].Path, w.start, w.kind, w.path)
+		}
+	}
+	if m[0].End != 0x452000 || m[0].Perms != "r-xp" || m[0].Inode != 1001 || m[0].Device != "fe:00" {
+		t.Errorf("region 0 = %+v, want end 0x452000, r-xp, fe:00, inode 1001", m[0])
+	}
+}
+
+// TestMemoryMapSmaps checks that smaps statistics attach to their regions
+func TestMemoryMapSmaps(t *testing.T) {
+	skipOn32Bit(t)
+	m, err := fixtureProc.memoryMap("4242")
+	if err != nil {
+		t.Fatalf("memoryMap() error = %v", err)
+	}
+
+	// smaps lists regions out of order; the result must be sorted
+	if len(m) != 3 || m[0].Start != 0x400000 || m[1].Kind != RegionHeap || m[2].Kind != RegionAnon {
+		t.Fatalf("memoryMap() = %+v, want text, heap, anon in address order", m)
+	}
+	if m[0].RSSBytes != 300*1024 || m[0].PSSBytes != 150*1024 {
+		t.Errorf("text RSS, PSS = %d, %d, want %d, %d", m[0].RSSBytes, m[0].PSSBytes, 300*1024, 150*1024)
+	}
+	if m[2].RSSBytes != 2048*1024 {
+		t.Errorf("anon RSS = %d, want %d", m[2].RSSBytes, 2048*1024)
+	}
+}
+
+// TestMemoryMapFind checks address lookup at region boundaries
+func TestMemoryMapFind(t *testing.T) {
+	skipOn32Bit(t)
+	f, _ := os.Open("testdata/proc/4242/maps")
+	defer f.Close()
+	m, _ := parseMemoryMap(f, "/usr/bin/app")
+
+	tests := []struct {
+		name   string
+		addr   uint64
+		want   RegionKind
+		wantOK bool
+	}{
+		{name: "first byte of text", addr: 0x400000, want: RegionText, wantOK: true},
+		{name: "last byte of text", addr: 0x451fff, want: RegionText, wantOK: true},
+		{name: "end is exclusive", addr: 0x452000, want: RegionData, wantOK: true},
+		{name: "inside Go heap", addr: 0xc000012345, want: RegionAnon, wantOK: true},
+		{name: "gap between regions", addr: 0x500000, wantOK: false},
+		{name: "below everything", addr: 0x1000, wantOK: false},
+		{name: "above everything", addr: 0x7fffffffffff, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := m.Find(uintptr(tt.addr))
+			if ok != tt.wantOK || got.Kind != tt.want {
+				t.Errorf("Find(%#x) = %s, %v, want %s, %v", tt.addr, got.Kind, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	if got := m.Annotate(0x500000); got != "0x500000 is not mapped" {
+		t.Errorf("Annotate() of a gap = %q", got)
+	}
+	if got := m.Annotate(0x1a2b010); got != "0x1a2b010 is in heap rw-p 0x1a2b000-0x1a4c000 (132 kB)" {
+		t.Errorf("Annotate() of the heap = %q", got)
+	}
+}
+
+// skipOn32Bit skips tests against the testdata/proc/4242 fixture, which was
+// captured from a 64-bit process and holds addresses no 32-bit uintptr can
+// represent
+func skipOn32Bit(t *testing.T) {
+	if strconv.IntSize < 64 {
+		t.Skip("testdata/proc/4242 holds 64-bit addresses")
+	}
+}
+
+// TestReadMemoryMap locates live variables in the current process
+func TestReadMemoryMap(t *testing.T) {
+	if _, err := os.Stat("/proc/self/maps"); err != nil {
+		t.Skip("no /proc on this system")
+	}
+
+	m, err := ReadMemoryMap()
+	if err != nil {
+		t.Fatalf("ReadMemoryMap() error = %v", err)
+	}
+
+	// CreateOnHeap's result escapes, so it must live in writable memory
+	heapValue := CreateOnHeap()
+	region, ok := m.Find(uintptr(unsafe.Pointer(heapValue)))
+	if !ok || !strings.HasPrefix(region.Perms, "rw") {
+		t.Errorf("CreateOnHeap() pointer is in %+v, want a writable region", region)
+	}
+
+	// Code lives in the executable's text segment
+	text := false
+	for _, r := range m {
+		text = text || r.Kind == RegionText
+	}
+	if !text {
+		t.Errorf("ReadMemoryMap() found no text region for the test binary")
+	}
+}
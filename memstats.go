@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/ErvinLinUB/go-advanced-lab/memstats"
+)
+
+/*----- Extension: Runtime Memory Statistics -----*/
+
+// Package-level sinks keep the compiler from discarding demo results, which
+// would also let it keep CreateOnHeap's variable on the stack after inlining
+var (
+	sinkInt   int
+	sinkPtr   *int
+	sinkSlice []int
+)
+
+// memstatsInput is the slice the higher-order demos work on
+var memstatsInput = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+// memstatsDemos maps each `analyzer memstats` demo to the call it measures
+var memstatsDemos = map[string]func(){
+	"stack":  func() { sinkInt = CreateOnStack() },
+	"heap":   func() { sinkPtr = CreateOnHeap() },
+	"apply":  func() { sinkSlice = Apply(memstatsInput, func(x int) int { return x * 2 }) },
+	"filter": func() { sinkSlice = Filter(memstatsInput, func(x int) bool { return x%2 == 0 }) },
+	"pipeline": func() {
+		sinkSlice = Pipeline(memstatsInput, func(x int) int { return x + 1 }, func(x int) int { return x * 2 })
+	},
+	"primes": func() { sinkSlice, _ = PrimesUpTo(100_000) },
+}
+
+// runMemstats handles `analyzer memstats [--runs <n>] <demo>`
+func runMemstats(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("memstats", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	runs := flags.Int("runs", 1000, "number of times to call the demo")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if flags.NArg() != 1 || *runs < 1 {
+		return fmt.Errorf("%w: expected one demo (%s) and --runs >= 1", errUsage, memstatsDemoNames())
+	}
+
+	demo, ok := memstatsDemos[flags.Arg(0)]
+	if !ok {
+		return fmt.Errorf("%w: unknown demo %q (%s)", errUsage, flags.Arg(0), memstatsDemoNames())
+	}
+	fmt.Fprint(stdout, memstats.Measure(*runs, demo))
+	return nil
+}
+
+// memstatsDemoNames lists the demos for error messages
+func memstatsDemoNames() string {
+	names := make([]string, 0, len(memstatsDemos))
+	for name := range memstatsDemos {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return fmt.Sprint(names)
+}
@@ -0,0 +1,174 @@
+// Package memstats measures what a function call costs the Go runtime. It
+// snapshots runtime.MemStats and runtime/metrics before and after the call
+// and reports the difference: heap allocations, bytes, GC cycles and pauses.
+package memstats
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/metrics"
+	"strings"
+	"time"
+)
+
+// Metrics lists the runtime/metrics samples recorded in every Snapshot
+// Histograms are reduced to their total number of observations.
+var Metrics = []string{
+	"/gc/heap/allocs:bytes",
+	"/gc/heap/allocs:objects",
+	"/gc/heap/frees:objects",
+	"/gc/cycles/total:gc-cycles",
+	"/gc/heap/goal:bytes",
+	"/sched/pauses/total/gc:seconds",
+}
+
+// Snapshot is the state of the runtime at one point in time
+type Snapshot struct {
+	Mem     runtime.MemStats
+	Metrics map[string]float64 // keyed by metric name; unsupported names are absent
+}
+
+// Take records a snapshot of the current runtime state
+func Take() Snapshot {
+	samples := newSamples()
+	var s Snapshot
+	runtime.ReadMemStats(&s.Mem)
+	metrics.Read(samples)
+	s.Metrics = sampleValues(samples)
+	return s
+}
+
+// Report is the difference between two snapshots
+type Report struct {
+	Runs       int             // number of times the function was called
+	Allocs     uint64          // heap objects allocated
+	Frees      uint64          // heap objects freed
+	Bytes      uint64          // heap bytes allocated
+	HeapDelta  int64           // change in live heap bytes
+	GCCycles   uint32          // completed GC cycles
+	PauseTotal time.Duration   // stop-the-world time spent in those cycles
+	Pauses     []time.Duration // individual pauses, oldest first (at most 256)
+	Metrics    map[string]float64
+}
+
+// Diff reports what happened between before and after
+func Diff(before, after Snapshot) Report {
+	r := Report{
+		Runs:       1,
+		Allocs:     after.Mem.Mallocs - before.Mem.Mallocs,
+		Frees:      after.Mem.Frees - before.Mem.Frees,
+		Bytes:      after.Mem.TotalAlloc - before.Mem.TotalAlloc,
+		HeapDelta:  int64(after.Mem.HeapAlloc) - int64(before.Mem.HeapAlloc),
+		GCCycles:   after.Mem.NumGC - before.Mem.NumGC,
+		PauseTotal: time.Duration(after.Mem.PauseTotalNs - before.Mem.PauseTotalNs),
+		Metrics:    make(map[string]float64, len(after.Metrics)),
+	}
+
+	// PauseNs is a ring buffer holding the most recent 256 pauses
+	n := min(r.GCCycles, uint32(len(after.Mem.PauseNs)))
+	for i := n; i > 0; i-- {
+		idx := (after.Mem.NumGC - i) % uint32(len(after.Mem.PauseNs))
+		r.Pauses = append(r.Pauses, time.Duration(after.Mem.PauseNs[idx]))
+	}
+
+	for name, value := range after.Metrics {
+		if old, ok := before.Metrics[name]; ok {
+			r.Metrics[name] = value - old
+		}
+	}
+	return r
+}
+
+// Measure calls fn runs times and reports the combined cost
+// A GC runs first so the live heap starts from a settled baseline, and the
+// sample buffers are allocated up front so the measurement itself does not
+// show up in Allocs.
+func Measure(runs int, fn func()) Report {
+	if runs < 1 {
+		runs = 1
+	}
+	beforeSamples, afterSamples := newSamples(), newSamples()
+	var before, after Snapshot
+
+	runtime.GC()
+	metrics.Read(beforeSamples)
+	runtime.ReadMemStats(&before.Mem)
+	for i := 0; i < runs; i++ {
+		fn()
+	}
+	runtime.ReadMemStats(&after.Mem)
+	metrics.Read(afterSamples)
+
+	before.Metrics = sampleValues(beforeSamples)
+	after.Metrics = sampleValues(afterSamples)
+	r := Diff(before, after)
+	r.Runs = runs
+	return r
+}
+
+// AllocsPerRun is the average number of heap allocations per call
+func (r Report) AllocsPerRun() float64 {
+	return float64(r.Allocs) / float64(max(r.Runs, 1))
+}
+
+// BytesPerRun is the average number of heap bytes allocated per call
+func (r Report) BytesPerRun() float64 {
+	return float64(r.Bytes) / float64(max(r.Runs, 1))
+}
+
+// String formats the report as aligned "name: value" lines
+func (r Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "runs:          %d\n", r.Runs)
+	fmt.Fprintf(&b, "allocs:        %d (%.2f/run)\n", r.Allocs, r.AllocsPerRun())
+	fmt.Fprintf(&b, "bytes:         %d (%.2f/run)\n", r.Bytes, r.BytesPerRun())
+	fmt.Fprintf(&b, "frees:         %d\n", r.Frees)
+	fmt.Fprintf(&b, "heap delta:    %+d\n", r.HeapDelta)
+	fmt.Fprintf(&b, "gc cycles:     %d\n", r.GCCycles)
+	fmt.Fprintf(&b, "gc pause:      %v\n", r.PauseTotal)
+	if len(r.Pauses) > 0 {
+		fmt.Fprintf(&b, "gc pauses:     %v\n", r.Pauses)
+	}
+	for _, name := range Metrics {
+		if value, ok := r.Metrics[name]; ok {
+			fmt.Fprintf(&b, "%s: %g\n", name, value)
+		}
+	}
+	return b.String()
+}
+
+// newSamples allocates a sample for every supported name in Metrics
+func newSamples() []metrics.Sample {
+	supported := make(map[string]bool)
+	for _, desc := range metrics.All() {
+		supported[desc.Name] = true
+	}
+
+	samples := make([]metrics.Sample, 0, len(Metrics))
+	for _, name := range Metrics {
+		if supported[name] {
+			samples = append(samples, metrics.Sample{Name: name})
+		}
+	}
+	return samples
+}
+
+// sampleValues flattens samples into a map of numbers
+func sampleValues(samples []metrics.Sample) map[string]float64 {
+	values := make(map[string]float64, len(samples))
+	for _, s := range samples {
+		switch s.Value.Kind() {
+		case metrics.KindUint64:
+			values[s.Name] = float64(s.Value.Uint64())
+		case metrics.KindFloat64:
+			values[s.Name] = s.Value.Float64()
+		case metrics.KindFloat64Histogram:
+			var count uint64
+			for _, c := range s.Value.Float64Histogram().Counts {
+				count += c
+			}
+			values[s.Name] = float64(count)
+		}
+	}
+	return values
+}
@@ -0,0 +1,83 @@
+package memstats
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+var sink *[64]byte
+
+func TestMeasure(t *testing.T) {
+	tests := []struct {
+		name       string
+		runs       int
+		fn         func()
+		wantAllocs uint64
+		wantBytes  uint64
+	}{
+		{name: "no allocation", runs: 100, fn: func() {}, wantAllocs: 0, wantBytes: 0},
+		{name: "one allocation per run", runs: 100, fn: func() { sink = new([64]byte) }, wantAllocs: 100, wantBytes: 6400},
+		{name: "runs below one count as one", runs: 0, fn: func() { sink = new([64]byte) }, wantAllocs: 1, wantBytes: 64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Measure(tt.runs, tt.fn)
+			if r.Allocs != tt.wantAllocs || r.Bytes != tt.wantBytes {
+				t.Errorf("Measure() allocs=%d bytes=%d, want allocs=%d bytes=%d", r.Allocs, r.Bytes, tt.wantAllocs, tt.wantBytes)
+			}
+		})
+	}
+}
+
+func TestMeasureCountsGC(t *testing.T) {
+	r := Measure(3, runtime.GC)
+	if r.GCCycles < 3 || len(r.Pauses) != int(r.GCCycles) {
+		t.Errorf("Measure(runtime.GC) cycles=%d pauses=%d, want at least 3 of each", r.GCCycles, len(r.Pauses))
+	}
+	if got := r.Metrics["/gc/cycles/total:gc-cycles"]; got != float64(r.GCCycles) {
+		t.Errorf("gc-cycles metric = %v, want %d", got, r.GCCycles)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	var before, after Snapshot
+	before.Mem.Mallocs, after.Mem.Mallocs = 10, 15
+	before.Mem.HeapAlloc, after.Mem.HeapAlloc = 1000, 400
+	before.Mem.NumGC, after.Mem.NumGC = 254, 258
+	before.Mem.PauseTotalNs, after.Mem.PauseTotalNs = 100, 1100
+	// Cycles 255..258 land in slots 254, 255, 0 and 1 of the ring buffer
+	after.Mem.PauseNs[254], after.Mem.PauseNs[255] = 100, 200
+	after.Mem.PauseNs[0], after.Mem.PauseNs[1] = 300, 400
+	before.Metrics = map[string]float64{"/a": 1}
+	after.Metrics = map[string]float64{"/a": 4, "/b": 2}
+
+	r := Diff(before, after)
+	if r.Allocs != 5 || r.HeapDelta != -600 || r.GCCycles != 4 || r.PauseTotal != time.Microsecond {
+		t.Errorf("Diff() = %+v", r)
+	}
+	wantPauses := []time.Duration{100, 200, 300, 400}
+	for i, want := range wantPauses {
+		if i >= len(r.Pauses) || r.Pauses[i] != want {
+			t.Fatalf("Diff() pauses = %v, want %v", r.Pauses, wantPauses)
+		}
+	}
+	if _, ok := r.Metrics["/b"]; ok || r.Metrics["/a"] != 3 {
+		t.Errorf("Diff() metrics = %v, want only /a = 3", r.Metrics)
+	}
+}
+
+func TestReportString(t *testing.T) {
+	r := Report{Runs: 4, Allocs: 2, Bytes: 32, Metrics: map[string]float64{"/gc/heap/allocs:objects": 2}}
+	out := r.String()
+	for _, want := range []string{"allocs:        2 (0.50/run)", "bytes:         32 (8.00/run)", "/gc/heap/allocs:objects: 2"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("String() = %q, missing %q", out, want)
+		}
+	}
+	if strings.Contains(out, "gc pauses") {
+		t.Errorf("String() = %q, want no pause list without GC cycles", out)
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+/*----- Extension: Runtime Memory Statistics -----*/
+
+func TestRunMemstats(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{name: "stack stays off the heap", args: []string{"stack"}, want: "allocs:        0 (0.00/run)"},
+		{name: "heap allocates once per call", args: []string{"--runs", "50", "heap"}, want: "allocs:        50 (1.00/run)"},
+		{name: "apply allocates its result", args: []string{"apply"}, want: "(1.00/run)"},
+		{name: "no demo", args: nil, wantErr: true},
+		{name: "unknown demo", args: []string{"queue"}, wantErr: true},
+		{name: "zero runs", args: []string{"--runs", "0", "heap"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout strings.Builder
+			err := runMemstats(tt.args, &stdout)
+			if tt.wantErr {
+				if !errors.Is(err, errUsage) {
+					t.Errorf("runMemstats() error = %v, want a usage error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("runMemstats() error = %v", err)
+			}
+			if !strings.Contains(stdout.String(), tt.want) {
+				t.Errorf("runMemstats() = %q, want it to contain %q", stdout.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"errors"
+	"math/bits"
+)
+
+/*----- Extension: Modular Arithmetic & Combinatorics -----*/
+
+// 1. FactorialMod - calculates n! mod m without overflowing
+func FactorialMod(n, m int) (int, error) {
+	if n < 0 {
+		return 0, errors.New("factorial is not defined for negative numbers")
+	}
+	if m <= 0 {
+		return 0, errors.New("modulus must be positive")
+	}
+
+	result := 1 % m
+	for i := 2; i <= n && result != 0; i++ {
+		result = mulMod(result, i%m, m)
+	}
+	return result, nil
+}
+
+// 2. PowerMod - calculates base^exponent mod m by repeated squaring
+func PowerMod(base, exponent, m int) (int, error) {
+	if exponent < 0 {
+		return 0, errors.New("negative exponents not supported, use ModInverse")
+	}
+	if m <= 0 {
+		return 0, errors.New("modulus must be positive")
+	}
+
+	result := 1 % m
+	base = normalizeMod(base, m)
+	for exponent > 0 {
+		if exponent&1 == 1 {
+			result = mulMod(result, base, m)
+		}
+		base = mulMod(base, base, m)
+		exponent >>= 1
+	}
+	return result, nil
+}
+
+// 3. ModInverse - finds x such that a*x ≡ 1 (mod m) using extended Euclid
+func ModInverse(a, m int) (int, error) {
+	if m <= 0 {
+		return 0, errors.New("modulus must be positive")
+	}
+
+	// Invariant: oldR ≡ oldS*a and r ≡ s*a (mod m)
+	oldR, r := normalizeMod(a, m), m
+	oldS, s := 1, 0
+	for r != 0 {
+		q := oldR / r
+		oldR, r = r, oldR-q*r
+		oldS, s = s, oldS-q*s
+	}
+
+	if oldR != 1 {
+		return 0, errors.New("no modular inverse: a and m are not coprime")
+	}
+	return normalizeMod(oldS, m), nil
+}
+
+// 4. MakeChooseMod - returns a closure computing C(n, k) mod p in O(1)
+// Precomputes factorial and inverse-factorial tables up to maxN, which must
+// be smaller than the prime p so every factorial is invertible.
+func MakeChooseMod(maxN, p int) (func(n, k int) (int, error), error) {
+	if maxN < 0 {
+		return nil, errors.New("table size must be non-negative")
+	}
+	if isPrime, _ := IsPrime(p); !isPrime {
+		return nil, errors.New("modulus must be prime")
+	}
+	if maxN >= p {
+		return nil, errors.New("table size must be smaller than the modulus")
+	}
+
+	fact := make([]int, maxN+1)
+	fact[0] = 1
+	for i := 1; i <= maxN; i++ {
+		fact[i] = mulMod(fact[i-1], i, p)
+	}
+
+	// One inverse via Fermat, then walk down: 1/(i-1)! = i * 1/i!
+	invFact := make([]int, maxN+1)
+	invFact[maxN], _ = PowerMod(fact[maxN], p-2, p)
+	for i := maxN; i > 0; i-- {
+		invFact[i-1] = mulMod(invFact[i], i, p)
+	}
+
+	return func(n, k int) (int, error) {
+		if n < 0 || k < 0 {
+			return 0, errors.New("choose requires non-negative n and k")
+		}
+		if n > maxN {
+			return 0, errors.New("n exceeds precomputed table size")
+		}
+		if k > n {
+			return 0, nil
+		}
+		return mulMod(fact[n], mulMod(invFact[k], invFact[n-k], p), p), nil
+	}, nil
+}
+
+// 5. LucasChoose - calculates C(n, k) mod p for arbitrarily large n
+// Lucas' theorem: C(n, k) ≡ ∏ C(n_i, k_i) (mod p) over the base-p digits of
+// n and k. Each digit is computed directly instead of from a table, so the
+// cost is O(min(k_i, n_i-k_i)) per digit and no O(p) memory is needed.
+func LucasChoose(n, k, p int) (int, error) {
+	if n < 0 || k < 0 {
+		return 0, errors.New("choose requires non-negative n and k")
+	}
+	if isPrime, _ := IsPrime(p); !isPrime {
+		return 0, errors.New("modulus must be prime")
+	}
+
+	result := 1
+	for (n > 0 || k > 0) && result != 0 {
+		digit, err := chooseDigitMod(n%p, k%p, p)
+		if err != nil {
+			return 0, err
+		}
+		result = mulMod(result, digit, p)
+		n, k = n/p, k/p
+	}
+	return result, nil
+}
+
+// chooseDigitMod calculates C(n, k) mod p for 0 <= n, k < p
+// n!/(k!(n-k)!) has no factor of p here, so the denominator is invertible.
+func chooseDigitMod(n, k, p int) (int, error) {
+	if k > n {
+		return 0, nil
+	}
+	k = min(k, n-k)
+
+	num, den := 1, 1
+	for i := 0; i < k; i++ {
+		num = mulMod(num, n-i, p)
+		den = mulMod(den, i+1, p)
+	}
+
+	inv, err := ModInverse(den, p)
+	if err != nil {
+		return 0, err
+	}
+	return mulMod(num, inv, p), nil
+}
+
+// mulMod calculates a*b mod m using a 128-bit intermediate product
+// a and b must already be reduced into [0, m)
+func mulMod(a, b, m int) int {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	return int(bits.Rem64(hi, lo, uint64(m)))
+}
+
+// normalizeMod reduces a into the range [0, m)
+func normalizeMod(a, m int) int {
+	a %= m
+	if a < 0 {
+		a += m
+	}
+	return a
+}
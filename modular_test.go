@@ -0,0 +1,184 @@
+package main
+
+import (
+	"strconv"
+	"testing"
+)
+
+/*----- Extension: Modular Arithmetic & Combinatorics -----*/
+
+const testPrime = 1_000_000_007
+
+// 1. FactorialMod
+func TestFactorialMod(t *testing.T) {
+	tests := []struct {
+		name    string
+		n, m    int
+		want    int
+		wantErr bool
+	}{
+		{name: "5! mod 7", n: 5, m: 7, want: 1, wantErr: false},
+		{name: "0! mod 13", n: 0, m: 13, want: 1, wantErr: false},
+		{name: "anything mod 1", n: 4, m: 1, want: 0, wantErr: false},
+		{name: "100! mod 1e9+7", n: 100, m: testPrime, want: 437918130, wantErr: false},
+		{name: "n >= m gives 0", n: 13, m: 13, want: 0, wantErr: false},
+		{name: "negative n", n: -1, m: 7, want: 0, wantErr: true},
+		{name: "zero modulus", n: 3, m: 0, want: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FactorialMod(tt.n, tt.m)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FactorialMod() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("FactorialMod() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// 2. PowerMod
+func TestPowerMod(t *testing.T) {
+	tests := []struct {
+		name      string
+		base, mod int
+		exponent  int64
+		want      int
+		wantErr   bool
+	}{
+		{name: "2^10 mod 1000", base: 2, exponent: 10, mod: 1000, want: 24, wantErr: false},
+		{name: "x^0 mod m", base: 9, exponent: 0, mod: 5, want: 1, wantErr: false},
+		{name: "negative base", base: -2, exponent: 3, mod: 5, want: 2, wantErr: false},
+		{name: "2^1e18 mod 1e9+7", base: 2, exponent: 1_000_000_000_000_000_000, mod: testPrime, want: 719476260, wantErr: false},
+		{name: "negative exponent", base: 2, exponent: -1, mod: 5, want: 0, wantErr: true},
+		{name: "negative modulus", base: 2, exponent: 3, mod: -5, want: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if is64BitOnly(tt.exponent) {
+				t.Skipf("%d does not fit in a %d-bit int", tt.exponent, strconv.IntSize)
+			}
+			got, err := PowerMod(tt.base, int(tt.exponent), tt.mod)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PowerMod() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PowerMod() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// 3. ModInverse
+func TestModInverse(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, m    int
+		want    int
+		wantErr bool
+	}{
+		{name: "3 mod 11", a: 3, m: 11, want: 4, wantErr: false},
+		{name: "3 mod 1e9+7", a: 3, m: testPrime, want: 333333336, wantErr: false},
+		{name: "composite modulus", a: 7, m: 40, want: 23, wantErr: false},
+		{name: "negative a", a: -3, m: 11, want: 7, wantErr: false},
+		{name: "not coprime", a: 6, m: 9, want: 0, wantErr: true},
+		{name: "zero modulus", a: 1, m: 0, want: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ModInverse(tt.a, tt.m)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ModInverse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ModInverse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// 4. MakeChooseMod
+func TestMakeChooseMod(t *testing.T) {
+	choose, err := MakeChooseMod(2000, testPrime)
+	if err != nil {
+		t.Fatalf("MakeChooseMod() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		n, k    int
+		want    int
+		wantErr bool
+	}{
+		{name: "5 choose 2", n: 5, k: 2, want: 10, wantErr: false},
+		{name: "1000 choose 500", n: 1000, k: 500, want: 159835829, wantErr: false},
+		{name: "k greater than n", n: 4, k: 9, want: 0, wantErr: false},
+		{name: "beyond table", n: 2001, k: 1, want: 0, wantErr: true},
+		{name: "negative k", n: 10, k: -1, want: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := choose(tt.n, tt.k)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("choose() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("choose() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("invalid construction", func(t *testing.T) {
+		if _, err := MakeChooseMod(10, 12); err == nil {
+			t.Errorf("MakeChooseMod(10, 12) error = nil, want non-prime error")
+		}
+		if _, err := MakeChooseMod(13, 13); err == nil {
+			t.Errorf("MakeChooseMod(13, 13) error = nil, want table size error")
+		}
+	})
+}
+
+// 5. LucasChoose
+func TestLucasChoose(t *testing.T) {
+	tests := []struct {
+		name    string
+		n       int64
+		k, p    int
+		want    int
+		wantErr bool
+	}{
+		{name: "small values mod 13", n: 1000, k: 301, p: 13, want: 3, wantErr: false},
+		{name: "2000 choose 1000 mod 997", n: 2000, k: 1000, p: 997, want: 40, wantErr: false},
+		{name: "huge n mod 1009", n: 987654321987654321, k: 123456789, p: 1009, want: 547, wantErr: false},
+		{name: "huge n mod 1000003", n: 1_000_000_000_000_000_000, k: 999, p: 1000003, want: 138335, wantErr: false},
+		{name: "huge n mod 1e9+7", n: 1 << 62, k: 12345, p: 1_000_000_007, want: 412683499, wantErr: false},
+		{name: "100 choose 50 mod 1e9+7", n: 100, k: 50, p: 1_000_000_007, want: 538992043, wantErr: false},
+		{name: "non-prime modulus", n: 10, k: 3, p: 10, want: 0, wantErr: true},
+		{name: "negative n", n: -10, k: 3, p: 7, want: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if is64BitOnly(tt.n) {
+				t.Skipf("%d does not fit in a %d-bit int", tt.n, strconv.IntSize)
+			}
+			got, err := LucasChoose(int(tt.n), tt.k, tt.p)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LucasChoose() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("LucasChoose() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+/*----- Extension: Generic Pointer Utilities -----*/
+
+// ErrNilPointer is returned by the Safe variants instead of panicking
+var ErrNilPointer = errors.New("nil pointer")
+
+// 1. Swap - exchanges the values a and b point to, for any type
+// Like SwapPointers, it panics if either pointer is nil; see SafeSwap.
+func Swap[T any](a, b *T) {
+	*a, *b = *b, *a
+}
+
+// 2. Ptr - returns a pointer to a copy of v, e.g. Ptr(42) or Ptr("name")
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// 3. Deref - returns the value p points to, or fallback when p is nil
+func Deref[T any](p *T, fallback T) T {
+	if p == nil {
+		return fallback
+	}
+	return *p
+}
+
+// 4. UpdateInPlace - replaces the value p points to with fn(*p)
+// UpdateInPlace(p, func(x int) int { return x * 2 }) is DoublePointer for
+// any type and any update. It panics if p is nil; see SafeUpdateInPlace.
+func UpdateInPlace[T any](p *T, fn func(T) T) {
+	*p = fn(*p)
+}
+
+// 5. SafeSwap - Swap that returns ErrNilPointer when either pointer is nil
+// Nothing is modified in that case.
+func SafeSwap[T any](a, b *T) error {
+	switch {
+	case a == nil && b == nil:
+		return fmt.Errorf("swap: both pointers: %w", ErrNilPointer)
+	case a == nil:
+		return fmt.Errorf("swap: first pointer: %w", ErrNilPointer)
+	case b == nil:
+		return fmt.Errorf("swap: second pointer: %w", ErrNilPointer)
+	}
+	Swap(a, b)
+	return nil
+}
+
+// 6. SafeUpdateInPlace - UpdateInPlace that returns ErrNilPointer when p is
+// nil, without calling fn
+func SafeUpdateInPlace[T any](p *T, fn func(T) T) error {
+	if p == nil {
+		return fmt.Errorf("update: %w", ErrNilPointer)
+	}
+	UpdateInPlace(p, fn)
+	return nil
+}
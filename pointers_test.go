@@ -0,0 +1,161 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+/*----- Extension: Generic Pointer Utilities -----*/
+
+// 1. Swap
+func TestSwap(t *testing.T) {
+	t.Run("ints", func(t *testing.T) {
+		a, b := 1, 2
+		Swap(&a, &b)
+		if a != 2 || b != 1 {
+			t.Errorf("Swap() = %d, %d, want 2, 1", a, b)
+		}
+	})
+
+	t.Run("strings", func(t *testing.T) {
+		a, b := "left", "right"
+		Swap(&a, &b)
+		if a != "right" || b != "left" {
+			t.Errorf("Swap() = %q, %q, want \"right\", \"left\"", a, b)
+		}
+	})
+
+	t.Run("structs", func(t *testing.T) {
+		type point struct{ X, Y int }
+		a, b := point{1, 2}, point{3, 4}
+		Swap(&a, &b)
+		if a != (point{3, 4}) || b != (point{1, 2}) {
+			t.Errorf("Swap() = %v, %v, want {3 4}, {1 2}", a, b)
+		}
+	})
+
+	t.Run("same pointer", func(t *testing.T) {
+		a := 5
+		Swap(&a, &a)
+		if a != 5 {
+			t.Errorf("Swap(&a, &a) = %d, want 5", a)
+		}
+	})
+
+	t.Run("nil pointer panics", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Swap() with a nil pointer did not panic")
+			}
+		}()
+		a := 1
+		Swap(&a, nil)
+	})
+}
+
+// 2. Ptr & 3. Deref
+func TestPtrAndDeref(t *testing.T) {
+	v := 10
+	p := Ptr(v)
+	*p = 20
+	if v != 10 {
+		t.Errorf("Ptr() shares storage with its argument: v = %d", v)
+	}
+	if Ptr(1) == Ptr(1) {
+		t.Error("Ptr() returned the same pointer twice")
+	}
+
+	tests := []struct {
+		name     string
+		p        *string
+		fallback string
+		want     string
+	}{
+		{name: "non-nil", p: Ptr("value"), fallback: "default", want: "value"},
+		{name: "nil", p: nil, fallback: "default", want: "default"},
+		{name: "zero value is not nil", p: Ptr(""), fallback: "default", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Deref(tt.p, tt.fallback); got != tt.want {
+				t.Errorf("Deref() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// 4. UpdateInPlace
+func TestUpdateInPlace(t *testing.T) {
+	x := 21
+	UpdateInPlace(&x, func(v int) int { return v * 2 })
+	if x != 42 {
+		t.Errorf("UpdateInPlace(double) = %d, want 42", x)
+	}
+
+	nums := []int{1, 2}
+	UpdateInPlace(&nums, func(s []int) []int { return append(s, 3) })
+	if len(nums) != 3 || nums[2] != 3 {
+		t.Errorf("UpdateInPlace(append) = %v, want [1 2 3]", nums)
+	}
+
+	// Matches DoublePointer
+	y := 7
+	z := 7
+	DoublePointer(&y)
+	UpdateInPlace(&z, func(v int) int { return v * 2 })
+	if y != z {
+		t.Errorf("UpdateInPlace = %d, DoublePointer = %d", z, y)
+	}
+}
+
+// 5. SafeSwap & 6. SafeUpdateInPlace
+func TestSafePointerFunctions(t *testing.T) {
+	one, two := 1, 2
+
+	tests := []struct {
+		name    string
+		a, b    *int
+		wantErr bool
+	}{
+		{name: "both valid", a: &one, b: &two, wantErr: false},
+		{name: "first nil", a: nil, b: &two, wantErr: true},
+		{name: "second nil", a: &one, b: nil, wantErr: true},
+		{name: "both nil", a: nil, b: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			one, two = 1, 2
+			err := SafeSwap(tt.a, tt.b)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SafeSwap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, ErrNilPointer) {
+					t.Errorf("SafeSwap() error = %v, want ErrNilPointer", err)
+				}
+				if one != 1 || two != 2 {
+					t.Errorf("SafeSwap() modified values on error: %d, %d", one, two)
+				}
+			} else if one != 2 || two != 1 {
+				t.Errorf("SafeSwap() = %d, %d, want 2, 1", one, two)
+			}
+		})
+	}
+
+	t.Run("update nil", func(t *testing.T) {
+		called := false
+		err := SafeUpdateInPlace(nil, func(v int) int { called = true; return v })
+		if !errors.Is(err, ErrNilPointer) || called {
+			t.Errorf("SafeUpdateInPlace(nil) error = %v, called = %v; want ErrNilPointer without calling fn", err, called)
+		}
+	})
+
+	t.Run("update valid", func(t *testing.T) {
+		x := 3
+		if err := SafeUpdateInPlace(&x, func(v int) int { return v * v }); err != nil || x != 9 {
+			t.Errorf("SafeUpdateInPlace() = %d, %v; want 9, nil", x, err)
+		}
+	})
+}
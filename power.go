@@ -0,0 +1,44 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"math/big"
+)
+
+/*----- Extension: Rational & Floating-Point Powers -----*/
+
+// 1. PowerRat - calculates base^exponent exactly as a rational number
+// Negative exponents produce the reciprocal, e.g. PowerRat(2, -3) = 1/8.
+func PowerRat(base, exponent int) (*big.Rat, error) {
+	if base == 0 && exponent < 0 {
+		return nil, errors.New("zero cannot be raised to a negative exponent")
+	}
+
+	// Work on |exponent| with big.Int so neither the result nor the negation
+	// of math.MinInt can overflow
+	abs := new(big.Int).Abs(big.NewInt(int64(exponent)))
+	value := new(big.Int).Exp(big.NewInt(int64(base)), abs, nil)
+
+	if exponent < 0 {
+		return new(big.Rat).SetFrac(big.NewInt(1), value), nil
+	}
+	return new(big.Rat).SetInt(value), nil
+}
+
+// 2. PowerFloat - calculates base^exponent as a float64
+// Precision: the result is exact whenever base^exponent is representable in
+// a float64 (integers up to 2^53 and powers of two such as 2^-3). Otherwise it
+// is the value of math.Pow, which is correctly rounded to within 1 ulp.
+// Results beyond ±1.8e308 are reported as an error instead of ±Inf.
+func PowerFloat(base, exponent int) (float64, error) {
+	if base == 0 && exponent < 0 {
+		return 0, errors.New("zero cannot be raised to a negative exponent")
+	}
+
+	result := math.Pow(float64(base), float64(exponent))
+	if math.IsInf(result, 0) {
+		return 0, errors.New("result overflows float64")
+	}
+	return result, nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+/*----- Extension: Rational & Floating-Point Powers -----*/
+
+// 1. PowerRat
+func TestPowerRat(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     int
+		exponent int
+		want     string
+		wantErr  bool
+	}{
+		{name: "2 to the -3", base: 2, exponent: -3, want: "1/8", wantErr: false},
+		{name: "-2 to the -3", base: -2, exponent: -3, want: "-1/8", wantErr: false},
+		{name: "10 to the -2", base: 10, exponent: -2, want: "1/100", wantErr: false},
+		{name: "5 to the 0", base: 5, exponent: 0, want: "1", wantErr: false},
+		{name: "2 to the 8", base: 2, exponent: 8, want: "256", wantErr: false},
+		{name: "2 to the 100", base: 2, exponent: 100, want: "1267650600228229401496703205376", wantErr: false},
+		{name: "1 to the MinInt", base: 1, exponent: math.MinInt, want: "1", wantErr: false},
+		{name: "-1 to the MinInt", base: -1, exponent: math.MinInt, want: "1", wantErr: false},
+		{name: "-1 to the MinInt+1", base: -1, exponent: math.MinInt + 1, want: "-1", wantErr: false},
+		{name: "zero to the -1", base: 0, exponent: -1, want: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PowerRat(tt.base, tt.exponent)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PowerRat() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			want, _ := new(big.Rat).SetString(tt.want)
+			if got.Cmp(want) != 0 {
+				t.Errorf("PowerRat() = %v, want %v", got.RatString(), tt.want)
+			}
+		})
+	}
+}
+
+// 2. PowerFloat
+func TestPowerFloat(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     int
+		exponent int
+		want     float64
+		wantErr  bool
+	}{
+		{name: "2 to the -3", base: 2, exponent: -3, want: 0.125, wantErr: false},
+		{name: "4 to the -1", base: 4, exponent: -1, want: 0.25, wantErr: false},
+		{name: "3 to the 4", base: 3, exponent: 4, want: 81, wantErr: false},
+		{name: "-2 to the 3", base: -2, exponent: 3, want: -8, wantErr: false},
+		{name: "2 to the 53", base: 2, exponent: 53, want: 9007199254740992, wantErr: false},
+		{name: "zero to the -2", base: 0, exponent: -2, want: 0, wantErr: true},
+		{name: "overflow", base: 10, exponent: 400, want: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PowerFloat(tt.base, tt.exponent)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PowerFloat() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PowerFloat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Power keeps rejecting negative exponents so callers choose explicitly
+func TestPowerNegativeExponentStillErrors(t *testing.T) {
+	if _, err := Power(2, -3); err == nil {
+		t.Errorf("Power(2, -3) error = nil, want error")
+	}
+}
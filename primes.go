@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+/*----- Extension: Prime Listing -----*/
+
+// maxSieveLimit bounds the sieve's []bool to about a gigabyte
+const maxSieveLimit = 1_000_000_000
+
+// 1. PrimesUpTo - lists every prime <= n using the sieve of Eratosthenes
+func PrimesUpTo(n int) ([]int, error) {
+	if n < 0 {
+		return nil, errors.New("prime listing requires a non-negative limit")
+	}
+	if n > maxSieveLimit {
+		return nil, fmt.Errorf("prime listing limit must be at most %d", maxSieveLimit)
+	}
+
+	// Only primes up to sqrt(n) cross anything off; i <= n/i avoids i*i overflow
+	composite := make([]bool, n+1)
+	for i := 2; i <= n/i; i++ {
+		if composite[i] {
+			continue
+		}
+		// Smaller multiples were already crossed off by smaller primes
+		for j := i * i; j <= n; j += i {
+			composite[j] = true
+		}
+	}
+
+	primes := []int{}
+	for i := 2; i <= n; i++ {
+		if !composite[i] {
+			primes = append(primes, i)
+		}
+	}
+	return primes, nil
+}
+
+// 2. Factorize - returns the prime factors of n in ascending order,
+// repeated by multiplicity, e.g. Factorize(12) = [2 2 3]
+func Factorize(n int) ([]int, error) {
+	if n < 2 {
+		return nil, errors.New("factorization requires number >= 2")
+	}
+
+	factors := []int{}
+	for p := 2; p <= n/p; p++ {
+		for n%p == 0 {
+			factors = append(factors, p)
+			n /= p
+		}
+	}
+
+	// Whatever is left has no divisor <= its square root, so it is prime
+	if n > 1 {
+		factors = append(factors, n)
+	}
+	return factors, nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+/*----- Extension: Prime Listing -----*/
+
+// 1. PrimesUpTo
+func TestPrimesUpTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   int
+		want    []int
+		wantErr bool
+	}{
+		{name: "limit 0", input: 0, want: []int{}, wantErr: false},
+		{name: "limit 2", input: 2, want: []int{2}, wantErr: false},
+		{name: "limit 30", input: 30, want: []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}, wantErr: false},
+		{name: "limit is prime", input: 13, want: []int{2, 3, 5, 7, 11, 13}, wantErr: false},
+		{name: "negative limit", input: -1, want: nil, wantErr: true},
+		{name: "limit above sieve bound", input: math.MaxInt - 1, want: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PrimesUpTo(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PrimesUpTo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("PrimesUpTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Every listed number must agree with IsPrime
+	t.Run("agrees with IsPrime", func(t *testing.T) {
+		primes, _ := PrimesUpTo(1000)
+		if len(primes) != 168 {
+			t.Errorf("PrimesUpTo(1000) found %d primes, want 168", len(primes))
+		}
+		for _, p := range primes {
+			if ok, _ := IsPrime(p); !ok {
+				t.Errorf("PrimesUpTo(1000) listed %d, which IsPrime rejects", p)
+			}
+		}
+	})
+}
+
+// 2. Factorize
+func TestFactorize(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   int64
+		want    []int
+		wantErr bool
+	}{
+		{name: "prime number 2", input: 2, want: []int{2}, wantErr: false},
+		{name: "prime number 97", input: 97, want: []int{97}, wantErr: false},
+		{name: "composite number 12", input: 12, want: []int{2, 2, 3}, wantErr: false},
+		{name: "power of two 1024", input: 1024, want: []int{2, 2, 2, 2, 2, 2, 2, 2, 2, 2}, wantErr: false},
+		{name: "square of a prime 49", input: 49, want: []int{7, 7}, wantErr: false},
+		{name: "large semiprime", input: 999983 * 1000003, want: []int{999983, 1000003}, wantErr: false},
+		{name: "number 1", input: 1, want: nil, wantErr: true},
+		{name: "negative number -6", input: -6, want: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if is64BitOnly(tt.input) {
+				t.Skipf("%d does not fit in a %d-bit int", tt.input, strconv.IntSize)
+			}
+			got, err := Factorize(int(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Factorize() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Factorize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*----- Extension: Process Inspection via /proc -----*/
+
+// clockTicks is USER_HZ, the unit of /proc time fields; Linux fixes it at
+// 100 for userspace regardless of the kernel's internal tick rate
+const clockTicks = 100
+
+// ProcessInfo describes a running process as reported by /proc
+type ProcessInfo struct {
+	PID         int       `json:"pid"`
+	PPID        int       `json:"ppid"`
+	UID         int       `json:"uid"`
+	GID         int       `json:"gid"`
+	Executable  string    `json:"executable"`
+	Cwd         string    `json:"cwd"`
+	CommandLine []string  `json:"command_line"`
+	EnvCount    int       `json:"env_count"`
+	StartTime   time.Time `json:"start_time"`
+	Threads     int       `json:"threads"`
+	RSSBytes    uint64    `json:"rss_bytes"`
+	VSZBytes    uint64    `json:"vsz_bytes"`
+}
+
+// GetProcessInfo describes the current process
+func GetProcessInfo() (ProcessInfo, error) {
+	return procFS{root: "/proc"}.processInfo("self")
+}
+
+// procFS reads process information from a procfs mount
+// The root is configurable so tests can point it at a fixture directory.
+type procFS struct {
+	root string
+}
+
+// procStat holds the fields of /proc/<pid>/stat that we use
+type procStat struct {
+	pid       int
+	comm      string
+	state     string
+	ppid      int
+	utime     uint64 // clock ticks spent in user mode
+	stime     uint64 // clock ticks spent in kernel mode
+	startTime uint64 // clock ticks after boot
+}
+
+// path joins elements onto the procfs root
+func (fs procFS) path(elem ...string) string {
+	return filepath.Join(append([]string{fs.root}, elem...)...)
+}
+
+// readStat parses /proc/<pid>/stat
+func (fs procFS) readStat(pid string) (procStat, error) {
+	data, err := os.ReadFile(fs.path(pid, "stat"))
+	if err != nil {
+		return procStat{}, err
+	}
+
+	// comm is wrapped in parentheses and may itself contain spaces or ')',
+	// so split around the last ')'
+	line := string(data)
+	open, end := strings.IndexByte(line, '('), strings.LastIndexByte(line, ')')
+	if open < 0 || end < open {
+		return procStat{}, fmt.Errorf("malformed stat for pid %s", pid)
+	}
+	fields := strings.Fields(line[end+1:])
+	if len(fields) < 20 {
+		return procStat{}, fmt.Errorf("malformed stat for pid %s: %d fields", pid, len(fields))
+	}
+
+	// fields[0] is field 3 (state) of proc(5)
+	var s procStat
+	var errs []error
+	s.pid, err = strconv.Atoi(strings.TrimSpace(line[:open]))
+	errs = append(errs, err)
+	s.comm = line[open+1 : end]
+	s.state = fields[0]
+	s.ppid, err = strconv.Atoi(fields[1])
+	errs = append(errs, err)
+	s.utime, err = strconv.ParseUint(fields[11], 10, 64)
+	errs = append(errs, err)
+	s.stime, err = strconv.ParseUint(fields[12], 10, 64)
+	errs = append(errs, err)
+	s.startTime, err = strconv.ParseUint(fields[19], 10, 64)
+	errs = append(errs, err)
+
+	if err := errors.Join(errs...); err != nil {
+		return procStat{}, fmt.Errorf("malformed stat for pid %s: %w", pid, err)
+	}
+	return s, nil
+}
+
+// readStatus parses the "Key:\tvalue" lines of /proc/<pid>/status
+func (fs procFS) readStatus(pid string) (map[string]string, error) {
+	data, err := os.ReadFile(fs.path(pid, "status"))
+	if err != nil {
+		return nil, err
+	}
+
+	status := map[string]string{}
+	for _, line := range strings.Split(string(data), "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if ok {
+			status[key] = strings.TrimSpace(value)
+		}
+	}
+	return status, nil
+}
+
+// bootTime reads the system boot time from the btime line of /proc/stat
+func (fs procFS) bootTime() (time.Time, error) {
+	data, err := os.ReadFile(fs.path("stat"))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if value, ok := strings.CutPrefix(line, "btime "); ok {
+			seconds, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("malformed btime: %w", err)
+			}
+			return time.Unix(seconds, 0), nil
+		}
+	}
+	return time.Time{}, errors.New("btime not found in stat")
+}
+
+// readNulList reads a NUL-separated file such as cmdline or environ
+func (fs procFS) readNulList(pid, name string) ([]string, error) {
+	data, err := os.ReadFile(fs.path(pid, name))
+	if err != nil {
+		return nil, err
+	}
+
+	data = bytes.TrimSuffix(data, []byte{0})
+	if len(data) == 0 {
+		return []string{}, nil
+	}
+	return strings.Split(string(data), "\x00"), nil
+}
+
+// processInfo gathers a ProcessInfo for pid ("self" for the caller)
+// exe, cwd and environ are often unreadable for other users' processes;
+// those fields are left empty rather than failing the whole lookup.
+func (fs procFS) processInfo(pid string) (ProcessInfo, error) {
+	stat, err := fs.readStat(pid)
+	if err != nil {
+		return ProcessInfo{}, err
+	}
+	status, err := fs.readStatus(pid)
+	if err != nil {
+		return ProcessInfo{}, err
+	}
+
+	info := ProcessInfo{PID: stat.pid, PPID: stat.ppid}
+	info.UID = firstField(status["Uid"])
+	info.GID = firstField(status["Gid"])
+	info.Threads, _ = strconv.Atoi(status["Threads"])
+	info.RSSBytes = parseKB(status["VmRSS"])
+	info.VSZBytes = parseKB(status["VmSize"])
+
+	info.Executable, _ = os.Readlink(fs.path(pid, "exe"))
+	info.Cwd, _ = os.Readlink(fs.path(pid, "cwd"))
+	info.CommandLine, _ = fs.readNulList(pid, "cmdline")
+	if env, err := fs.readNulList(pid, "environ"); err == nil {
+		info.EnvCount = len(env)
+	}
+
+	if boot, err := fs.bootTime(); err == nil {
+		// Scale by 10ms per tick; multiplying by time.Second first overflows
+		info.StartTime = boot.Add(time.Duration(stat.startTime) * (time.Second / clockTicks))
+	}
+	return info, nil
+}
+
+// firstField parses the first number of a status value like "1000\t1000\t..."
+func firstField(value string) int {
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return 0
+	}
+	n, _ := strconv.Atoi(fields[0])
+	return n
+}
+
+// parseKB converts a status value like "1792 kB" to bytes
+func parseKB(value string) uint64 {
+	n, _ := strconv.ParseUint(strings.TrimSuffix(value, " kB"), 10, 64)
+	return n * 1024
+}
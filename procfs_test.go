@@ -0,0 +1,102 @@
+package main
+
+import (
+	"os"
+	"slices"
+	"testing"
+	"time"
+)
+
+/*----- Extension: Process Inspection via /proc -----*/
+
+// fixtureProc is a fake /proc tree under testdata
+var fixtureProc = procFS{root: "testdata/proc"}
+
+// TestProcessInfoFixture parses every field from the fake /proc
+func TestProcessInfoFixture(t *testing.T) {
+	info, err := fixtureProc.processInfo("4242")
+	if err != nil {
+		t.Fatalf("processInfo() error = %v", err)
+	}
+
+	want := ProcessInfo{
+		PID:         4242,
+		PPID:        1,
+		UID:         1000,
+		GID:         100,
+		Executable:  "/usr/bin/app",
+		Cwd:         "/srv/app",
+		CommandLine: []string{"/usr/bin/app", "--port", "8080"},
+		EnvCount:    3,
+		// btime 1700000000 plus 12345 ticks at 100 Hz
+		StartTime: time.Unix(1700000000, 0).Add(123450 * time.Millisecond),
+		Threads:   3,
+		RSSBytes:  10240 * 1024,
+		VSZBytes:  102400 * 1024,
+	}
+
+	if info.PID != want.PID || info.PPID != want.PPID || info.UID != want.UID || info.GID != want.GID {
+		t.Errorf("ids = %d/%d/%d/%d, want %d/%d/%d/%d", info.PID, info.PPID, info.UID, info.GID, want.PID, want.PPID, want.UID, want.GID)
+	}
+	if info.Executable != want.Executable || info.Cwd != want.Cwd {
+		t.Errorf("exe, cwd = %q, %q, want %q, %q", info.Executable, info.Cwd, want.Executable, want.Cwd)
+	}
+	if !slices.Equal(info.CommandLine, want.CommandLine) {
+		t.Errorf("CommandLine = %q, want %q", info.CommandLine, want.CommandLine)
+	}
+	if info.EnvCount != want.EnvCount || info.Threads != want.Threads {
+		t.Errorf("EnvCount, Threads = %d, %d, want %d, %d", info.EnvCount, info.Threads, want.EnvCount, want.Threads)
+	}
+	if !info.StartTime.Equal(want.StartTime) {
+		t.Errorf("StartTime = %v, want %v", info.StartTime, want.StartTime)
+	}
+	if info.RSSBytes != want.RSSBytes || info.VSZBytes != want.VSZBytes {
+		t.Errorf("RSS, VSZ = %d, %d, want %d, %d", info.RSSBytes, info.VSZBytes, want.RSSBytes, want.VSZBytes)
+	}
+}
+
+// TestReadStat checks the comm parsing edge cases of /proc/<pid>/stat
+func TestReadStat(t *testing.T) {
+	stat, err := fixtureProc.readStat("4242")
+	if err != nil {
+		t.Fatalf("readStat() error = %v", err)
+	}
+	if stat.comm != "my (odd) app" {
+		t.Errorf("comm = %q, want %q", stat.comm, "my (odd) app")
+	}
+	if stat.state != "S" || stat.utime != 250 || stat.stime != 75 {
+		t.Errorf("state, utime, stime = %s, %d, %d, want S, 250, 75", stat.state, stat.utime, stat.stime)
+	}
+
+	if _, err := fixtureProc.readStat("9999"); err == nil {
+		t.Errorf("readStat() of a missing pid error = nil, want error")
+	}
+}
+
+// TestGetProcessInfo checks the live /proc/self against the os package
+func TestGetProcessInfo(t *testing.T) {
+	if _, err := os.Stat("/proc/self/stat"); err != nil {
+		t.Skip("no /proc on this system")
+	}
+
+	info, err := GetProcessInfo()
+	if err != nil {
+		t.Fatalf("GetProcessInfo() error = %v", err)
+	}
+	if info.PID != os.Getpid() || info.PPID != os.Getppid() {
+		t.Errorf("PID, PPID = %d, %d, want %d, %d", info.PID, info.PPID, os.Getpid(), os.Getppid())
+	}
+	if info.UID != os.Getuid() || info.GID != os.Getgid() {
+		t.Errorf("UID, GID = %d, %d, want %d, %d", info.UID, info.GID, os.Getuid(), os.Getgid())
+	}
+	if exe, _ := os.Executable(); info.Executable != exe {
+		t.Errorf("Executable = %q, want %q", info.Executable, exe)
+	}
+	if info.Threads < 1 || info.RSSBytes == 0 || info.VSZBytes < info.RSSBytes {
+		t.Errorf("Threads, RSS, VSZ = %d, %d, %d, want plausible values", info.Threads, info.RSSBytes, info.VSZBytes)
+	}
+	// Allow for clock adjustments since boot, which skew the computed time
+	if age := time.Since(info.StartTime); age < -time.Minute || age > time.Hour {
+		t.Errorf("StartTime = %v, want within the last hour", info.StartTime)
+	}
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+/*----- Extension: Process Tree Explorer -----*/
+
+// ProcessNode is one process in the parent/child hierarchy
+type ProcessNode struct {
+	PID      int
+	PPID     int
+	UID      int
+	Name     string // kernel's short name (comm)
+	Command  string // full command line, or [name] for kernel threads
+	State    string
+	RSSBytes uint64
+	CPUTime  time.Duration // user + system time
+	Children []*ProcessNode
+}
+
+// ProcessTree walks /proc and returns the root processes, each with its
+// descendants attached, ordered by PID
+func ProcessTree() ([]*ProcessNode, error) {
+	return procFS{root: "/proc"}.processTree()
+}
+
+// processTree builds the tree from every numeric directory under the root
+// Processes can exit while we walk; those are skipped rather than failing.
+func (fs procFS) processTree() ([]*ProcessNode, error) {
+	entries, err := os.ReadDir(fs.root)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := map[int]*ProcessNode{}
+	for _, entry := range entries {
+		if _, err := strconv.Atoi(entry.Name()); err != nil || !entry.IsDir() {
+			continue
+		}
+		node, err := fs.processNode(entry.Name())
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		nodes[node.PID] = node
+	}
+
+	// Attach each process to its parent; orphans (PPID 0 or a parent we
+	// could not see) become roots
+	var roots []*ProcessNode
+	for _, node := range nodes {
+		if parent, ok := nodes[node.PPID]; ok && node.PPID != node.PID {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+	sortByPID(roots)
+	for _, node := range nodes {
+		sortByPID(node.Children)
+	}
+	return roots, nil
+}
+
+// processNode reads a single process without its children
+func (fs procFS) processNode(pid string) (*ProcessNode, error) {
+	stat, err := fs.readStat(pid)
+	if err != nil {
+		return nil, err
+	}
+	status, err := fs.readStatus(pid)
+	if err != nil {
+		return nil, err
+	}
+
+	node := &ProcessNode{
+		PID:      stat.pid,
+		PPID:     stat.ppid,
+		UID:      firstField(status["Uid"]),
+		Name:     stat.comm,
+		State:    stat.state,
+		RSSBytes: parseKB(status["VmRSS"]),
+		CPUTime:  time.Duration(stat.utime+stat.stime) * (time.Second / clockTicks),
+	}
+
+	// Kernel threads have an empty cmdline; ps shows them as [name]
+	args, _ := fs.readNulList(pid, "cmdline")
+	node.Command = strings.Join(args, " ")
+	if node.Command == "" {
+		node.Command = "[" + stat.comm + "]"
+	}
+	return node, nil
+}
+
+// FilterProcessTree keeps processes matching keep, plus their ancestors so
+// every match still shows where it sits in the hierarchy
+func FilterProcessTree(roots []*ProcessNode, keep func(*ProcessNode) bool) []*ProcessNode {
+	result := []*ProcessNode{}
+	for _, node := range roots {
+		children := FilterProcessTree(node.Children, keep)
+		if keep(node) || len(children) > 0 {
+			filtered := *node
+			filtered.Children = children
+			result = append(result, &filtered)
+		}
+	}
+	return result
+}
+
+// sortByPID orders sibling processes by PID
+func sortByPID(nodes []*ProcessNode) {
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].PID < nodes[j].PID })
+}
+
+// runPS handles `analyzer ps [--tree] [--user <name|uid>] [--name <text>]`
+func runPS(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("ps", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	tree := flags.Bool("tree", false, "show the parent/child hierarchy")
+	userFilter := flags.String("user", "", "only processes owned by this user name or UID")
+	nameFilter := flags.String("name", "", "only processes whose name or command contains this text")
+	root := flags.String("proc", "/proc", "procfs mount to read")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if flags.NArg() != 0 {
+		return fmt.Errorf("%w: unexpected argument %q", errUsage, flags.Arg(0))
+	}
+
+	roots, err := procFS{root: *root}.processTree()
+	if err != nil {
+		return err
+	}
+
+	// Every filter given must match
+	keeps := []func(*ProcessNode) bool{}
+	if *userFilter != "" {
+		uid, err := lookupUID(*userFilter)
+		if err != nil {
+			return err
+		}
+		keeps = append(keeps, func(p *ProcessNode) bool { return p.UID == uid })
+	}
+	if *nameFilter != "" {
+		keeps = append(keeps, func(p *ProcessNode) bool {
+			return strings.Contains(p.Name, *nameFilter) || strings.Contains(p.Command, *nameFilter)
+		})
+	}
+	keep := func(p *ProcessNode) bool {
+		for _, k := range keeps {
+			if !k(p) {
+				return false
+			}
+		}
+		return true
+	}
+
+	fmt.Fprintf(stdout, "%7s %5s %10s %10s  %s\n", "PID", "STATE", "RSS", "CPU", "COMMAND")
+	if *tree {
+		printProcessTree(stdout, FilterProcessTree(roots, keep), "", true)
+		return nil
+	}
+
+	// The flat list shows only the matches themselves, without ancestors
+	var flat []*ProcessNode
+	var collect func(nodes []*ProcessNode)
+	collect = func(nodes []*ProcessNode) {
+		for _, node := range nodes {
+			if keep(node) {
+				flat = append(flat, node)
+			}
+			collect(node.Children)
+		}
+	}
+	collect(roots)
+	sortByPID(flat)
+	for _, node := range flat {
+		printProcessLine(stdout, node, "")
+	}
+	return nil
+}
+
+// printProcessTree draws the hierarchy with box-drawing branches
+// Roots are printed flush left; their descendants are indented below them.
+func printProcessTree(w io.Writer, nodes []*ProcessNode, indent string, root bool) {
+	for i, node := range nodes {
+		prefix, childIndent := "├─ ", indent+"│  "
+		if i == len(nodes)-1 {
+			prefix, childIndent = "└─ ", indent+"   "
+		}
+		if root {
+			prefix, childIndent = "", ""
+		}
+
+		printProcessLine(w, node, indent+prefix)
+		printProcessTree(w, node.Children, childIndent, false)
+	}
+}
+
+// printProcessLine prints one row of the ps table
+func printProcessLine(w io.Writer, node *ProcessNode, indent string) {
+	rss := "-"
+	if node.RSSBytes > 0 {
+		rss = strconv.FormatUint(node.RSSBytes/1024, 10) + "k"
+	}
+	// Like ps, show control characters in arguments as '?' to keep one row per process
+	command := strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return '?'
+		}
+		return r
+	}, node.Command)
+	fmt.Fprintf(w, "%7d %5s %10s %10s  %s%s\n", node.PID, node.State, rss, node.CPUTime, indent, command)
+}
+
+// lookupUID resolves a user name or numeric UID
+func lookupUID(name string) (int, error) {
+	if uid, err := strconv.Atoi(name); err == nil {
+		return uid, nil
+	}
+	u, err := user.Lookup(name)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(u.Uid)
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+/*----- Extension: Process Tree Explorer -----*/
+
+// TestProcessTree builds the hierarchy from the fake /proc
+func TestProcessTree(t *testing.T) {
+	roots, err := fixtureProc.processTree()
+	if err != nil {
+		t.Fatalf("processTree() error = %v", err)
+	}
+
+	// init (1) -> app (4242) -> worker (4300); kthreadd (2) -> kworker (77)
+	if len(roots) != 2 || roots[0].PID != 1 || roots[1].PID != 2 {
+		t.Fatalf("roots = %v, want PIDs 1 and 2", pids(roots))
+	}
+	app := roots[0].Children
+	if len(app) != 1 || app[0].PID != 4242 || len(app[0].Children) != 1 || app[0].Children[0].PID != 4300 {
+		t.Errorf("children of 1 = %v, want 4242 -> 4300", pids(app))
+	}
+
+	worker := app[0].Children[0]
+	if worker.Command != "worker --queue jobs" || worker.Name != "worker" || worker.State != "R" {
+		t.Errorf("worker = %q (%s, %s), want command %q in state R", worker.Command, worker.Name, worker.State, "worker --queue jobs")
+	}
+	if worker.UID != 1000 || worker.RSSBytes != 5120*1024 {
+		t.Errorf("worker UID, RSS = %d, %d, want 1000, %d", worker.UID, worker.RSSBytes, 5120*1024)
+	}
+	// 6000 + 1200 ticks at 100 Hz
+	if worker.CPUTime != 72*time.Second {
+		t.Errorf("worker CPUTime = %v, want 1m12s", worker.CPUTime)
+	}
+
+	if kworker := roots[1].Children[0]; kworker.Command != "[kworker/0:1]" {
+		t.Errorf("kernel thread command = %q, want [kworker/0:1]", kworker.Command)
+	}
+}
+
+// TestFilterProcessTree keeps matches and the ancestors leading to them
+func TestFilterProcessTree(t *testing.T) {
+	roots, _ := fixtureProc.processTree()
+
+	tests := []struct {
+		name string
+		keep func(*ProcessNode) bool
+		want []int // every remaining PID in depth-first order
+	}{
+		{name: "keep everything", keep: func(*ProcessNode) bool { return true }, want: []int{1, 4242, 4300, 2, 77}},
+		{name: "by user keeps ancestors", keep: func(p *ProcessNode) bool { return p.UID == 1000 }, want: []int{1, 4242, 4300}},
+		{name: "leaf match", keep: func(p *ProcessNode) bool { return p.Name == "kworker/0:1" }, want: []int{2, 77}},
+		{name: "no match", keep: func(*ProcessNode) bool { return false }, want: []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []int{}
+			var walk func(nodes []*ProcessNode)
+			walk = func(nodes []*ProcessNode) {
+				for _, node := range nodes {
+					got = append(got, node.PID)
+					walk(node.Children)
+				}
+			}
+			walk(FilterProcessTree(roots, tt.keep))
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("FilterProcessTree() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("FilterProcessTree() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	t.Run("original tree is untouched", func(t *testing.T) {
+		FilterProcessTree(roots, func(*ProcessNode) bool { return false })
+		if len(roots[0].Children) != 1 {
+			t.Errorf("FilterProcessTree() modified its input")
+		}
+	})
+}
+
+// TestRunPS checks the ps command output against the fixture
+func TestRunPS(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "tree",
+			args: []string{"--tree"},
+			want: "" +
+				"    PID STATE        RSS        CPU  COMMAND\n" +
+				"      1     S     12000k       4.2s  /sbin/init\n" +
+				"   4242     S     10240k      3.25s  └─ /usr/bin/app --port 8080\n" +
+				"   4300     R      5120k      1m12s     └─ worker --queue jobs\n" +
+				"      2     S          -      400ms  [kthreadd]\n" +
+				"     77     I          -        15s  └─ [kworker/0:1]\n",
+		},
+		{
+			name: "flat list sorted by pid",
+			args: []string{"--name", "k"},
+			want: "" +
+				"    PID STATE        RSS        CPU  COMMAND\n" +
+				"      2     S          -      400ms  [kthreadd]\n" +
+				"     77     I          -        15s  [kworker/0:1]\n" +
+				"   4300     R      5120k      1m12s  worker --queue jobs\n",
+		},
+		{
+			name: "user filter by uid",
+			args: []string{"--tree", "--user", "1000", "--name", "worker"},
+			want: "" +
+				"    PID STATE        RSS        CPU  COMMAND\n" +
+				"      1     S     12000k       4.2s  /sbin/init\n" +
+				"   4242     S     10240k      3.25s  └─ /usr/bin/app --port 8080\n" +
+				"   4300     R      5120k      1m12s     └─ worker --queue jobs\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			args := append([]string{"--proc", "testdata/proc"}, tt.args...)
+			if err := runPS(args, &stdout); err != nil {
+				t.Fatalf("runPS() error = %v", err)
+			}
+			if stdout.String() != tt.want {
+				t.Errorf("runPS() output =\n%s\nwant\n%s", stdout.String(), tt.want)
+			}
+		})
+	}
+}
+
+// pids lists the PIDs of a slice of nodes for error messages
+func pids(nodes []*ProcessNode) []int {
+	out := []int{}
+	for _, node := range nodes {
+		out = append(out, node.PID)
+	}
+	return out
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/ErvinLinUB/go-advanced-lab/proptest"
+)
+
+/*----- Property Tests: Higher-Order Functions -----*/
+
+// Generators shared by the properties below
+var (
+	propInts   = proptest.SliceOf(proptest.Int(-1_000_000, 1_000_000))
+	propInt    = proptest.Int(-1_000_000, 1_000_000)
+	propLinear = proptest.Zip(proptest.Int(-10, 10), proptest.Int(-100, 100))
+)
+
+// linearFunc turns a generated (a, b) pair into x -> a*x + b
+// Functions themselves cannot be shrunk, but their coefficients can.
+func linearFunc(p proptest.Pair[int, int]) func(int) int {
+	return func(x int) int { return p.First*x + p.Second }
+}
+
+func identity(x int) int { return x }
+
+// 1. Apply preserves length and applies the function element-wise
+func TestApplyProperties(t *testing.T) {
+	proptest.Check(t, proptest.Zip(propInts, propLinear), func(in proptest.Pair[[]int, proptest.Pair[int, int]]) error {
+		nums, f := in.First, linearFunc(in.Second)
+		got := Apply(nums, f)
+		if len(got) != len(nums) {
+			return fmt.Errorf("len(Apply()) = %d, want %d", len(got), len(nums))
+		}
+		for i := range nums {
+			if got[i] != f(nums[i]) {
+				return fmt.Errorf("Apply()[%d] = %d, want %d", i, got[i], f(nums[i]))
+			}
+		}
+		return nil
+	})
+}
+
+// 2. Filter returns exactly the matching elements, in their original order
+func TestFilterProperties(t *testing.T) {
+	modulus := proptest.Int(1, 7)
+	proptest.Check(t, proptest.Zip(propInts, modulus), func(in proptest.Pair[[]int, int]) error {
+		nums, m := in.First, in.Second
+		keep := func(x int) bool { return x%m == 0 }
+		got := Filter(nums, keep)
+
+		// Walk nums once: every output element must appear in order, and
+		// every skipped element must fail the predicate
+		j := 0
+		for _, x := range nums {
+			if j < len(got) && got[j] == x && keep(x) {
+				j++
+			} else if keep(x) {
+				return fmt.Errorf("Filter() dropped %d, which matches", x)
+			}
+		}
+		if j != len(got) {
+			return fmt.Errorf("Filter() = %v is not a subsequence of %v", got, nums)
+		}
+		return nil
+	})
+}
+
+// 3. Reduce with + equals the sum, starting from the initial value
+func TestReduceProperties(t *testing.T) {
+	proptest.Check(t, proptest.Zip(propInts, propInt), func(in proptest.Pair[[]int, int]) error {
+		nums, initial := in.First, in.Second
+		want := initial
+		for _, x := range nums {
+			want += x
+		}
+		if got := Reduce(nums, initial, func(acc, x int) int { return acc + x }); got != want {
+			return fmt.Errorf("Reduce(%v, %d, +) = %d, want %d", nums, initial, got, want)
+		}
+		return nil
+	})
+}
+
+// 4. Compose with identity on either side is the function itself
+func TestComposeProperties(t *testing.T) {
+	proptest.Check(t, proptest.Zip(propLinear, propInt), func(in proptest.Pair[proptest.Pair[int, int], int]) error {
+		f, x := linearFunc(in.First), in.Second
+		if got := Compose(f, identity)(x); got != f(x) {
+			return fmt.Errorf("Compose(f, identity)(%d) = %d, want %d", x, got, f(x))
+		}
+		if got := Compose(identity, f)(x); got != f(x) {
+			return fmt.Errorf("Compose(identity, f)(%d) = %d, want %d", x, got, f(x))
+		}
+		return nil
+	})
+}
+
+// 5. Pipeline without operations is the identity, and never mutates its input
+func TestPipelineProperties(t *testing.T) {
+	proptest.Check(t, propInts, func(nums []int) error {
+		if got := Pipeline(nums); !slices.Equal(got, nums) {
+			return fmt.Errorf("Pipeline(%v) = %v, want a copy", nums, got)
+		}
+		return nil
+	})
+
+	proptest.Check(t, proptest.Zip(propInts, proptest.SliceOf(propLinear)), func(in proptest.Pair[[]int, []proptest.Pair[int, int]]) error {
+		nums := in.First
+		before := slices.Clone(nums)
+		ops := make([]func(int) int, len(in.Second))
+		for i, p := range in.Second {
+			ops[i] = linearFunc(p)
+		}
+
+		got := Pipeline(nums, ops...)
+		if !slices.Equal(nums, before) {
+			return fmt.Errorf("Pipeline() changed its input from %v to %v", before, nums)
+		}
+		want := nums
+		for _, op := range ops {
+			want = Apply(want, op)
+		}
+		if !slices.Equal(got, want) {
+			return fmt.Errorf("Pipeline() = %v, chained Apply = %v", got, want)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,109 @@
+package proptest
+
+import "math/rand/v2"
+
+// Int generates integers in [lo, hi] that shrink toward the value in range
+// closest to zero
+// Values stay within size*size of that target, so early runs use small
+// numbers and later runs spread out.
+func Int(lo, hi int) Gen[int] {
+	target := min(max(0, lo), hi)
+	return Gen[int]{
+		Generate: func(r *rand.Rand, size int) int {
+			spread := size*size + 1
+			from, to := lo, hi
+			// Unsigned differences cannot overflow, even for math.MinInt
+			if uint64(target)-uint64(lo) > uint64(spread) {
+				from = target - spread
+			}
+			if uint64(hi)-uint64(target) > uint64(spread) {
+				to = target + spread
+			}
+			return from + int(r.Uint64N(uint64(to)-uint64(from)+1))
+		},
+		Shrink: func(v int) []int {
+			return shrinkInt(v, target)
+		},
+	}
+}
+
+// shrinkInt proposes target first, then values ever closer to v
+func shrinkInt(v, target int) []int {
+	var candidates []int
+	for d := v - target; d != 0; d /= 2 {
+		candidates = append(candidates, v-d)
+	}
+	return candidates
+}
+
+// SliceOf generates slices of up to size elements
+// Slices shrink by dropping halves, then single elements, then by shrinking
+// one element at a time.
+func SliceOf[T any](elem Gen[T]) Gen[[]T] {
+	return Gen[[]T]{
+		Generate: func(r *rand.Rand, size int) []T {
+			s := make([]T, r.IntN(size+1))
+			for i := range s {
+				s[i] = elem.Generate(r, size)
+			}
+			return s
+		},
+		Shrink: func(s []T) [][]T {
+			if len(s) == 0 {
+				return nil
+			}
+
+			candidates := [][]T{{}}
+			if half := len(s) / 2; half > 0 {
+				candidates = append(candidates, clone(s[:half]), clone(s[half:]))
+			}
+			for i := range s {
+				candidates = append(candidates, append(clone(s[:i]), s[i+1:]...))
+			}
+			if elem.Shrink != nil {
+				for i, v := range s {
+					for _, smaller := range elem.Shrink(v) {
+						c := clone(s)
+						c[i] = smaller
+						candidates = append(candidates, c)
+					}
+				}
+			}
+			return candidates
+		},
+	}
+}
+
+// Pair holds two generated values
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Zip generates pairs, shrinking one side at a time
+func Zip[A, B any](a Gen[A], b Gen[B]) Gen[Pair[A, B]] {
+	return Gen[Pair[A, B]]{
+		Generate: func(r *rand.Rand, size int) Pair[A, B] {
+			return Pair[A, B]{First: a.Generate(r, size), Second: b.Generate(r, size)}
+		},
+		Shrink: func(p Pair[A, B]) []Pair[A, B] {
+			var candidates []Pair[A, B]
+			if a.Shrink != nil {
+				for _, first := range a.Shrink(p.First) {
+					candidates = append(candidates, Pair[A, B]{First: first, Second: p.Second})
+				}
+			}
+			if b.Shrink != nil {
+				for _, second := range b.Shrink(p.Second) {
+					candidates = append(candidates, Pair[A, B]{First: p.First, Second: second})
+				}
+			}
+			return candidates
+		},
+	}
+}
+
+// clone copies s into a new slice so candidates never share storage
+func clone[T any](s []T) []T {
+	return append([]T(nil), s...)
+}
@@ -0,0 +1,123 @@
+// Package proptest is a small property-based testing framework. A Gen
+// produces random inputs of growing size; Check runs a property against many
+// of them and, when one fails, shrinks it to a minimal counterexample before
+// reporting it together with the seed that reproduces the run.
+package proptest
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+	"time"
+)
+
+// Gen generates random values of type T and proposes simpler versions of them
+type Gen[T any] struct {
+	// Generate returns a random value; size grows from 0 to Config.MaxSize
+	// over a run so early inputs are small
+	Generate func(r *rand.Rand, size int) T
+
+	// Shrink returns simpler candidates for v, simplest first
+	// A nil Shrink means values cannot be shrunk.
+	Shrink func(v T) []T
+}
+
+// Config controls a property run
+type Config struct {
+	Runs       int    // inputs to try; default 100
+	MaxSize    int    // largest size passed to Generate; default 100
+	MaxShrinks int    // shrinking steps before giving up; default 1000
+	Seed       uint64 // 0 picks a seed from the clock
+}
+
+// Failure describes a property that did not hold
+type Failure[T any] struct {
+	Seed     uint64
+	Run      int   // 1-based index of the first failing input
+	Original T     // the failing input as generated
+	Shrunk   T     // the simplest failing input found
+	Shrinks  int   // successful shrinking steps from Original to Shrunk
+	Err      error // what the property reported for Shrunk
+}
+
+// Error formats the failure with everything needed to reproduce it
+func (f *Failure[T]) Error() string {
+	return fmt.Sprintf("property failed on run %d (seed %d) after %d shrinks\n  input:    %#v\n  original: %#v\n  error:    %v",
+		f.Run, f.Seed, f.Shrinks, f.Shrunk, f.Original, f.Err)
+}
+
+// withDefaults fills in zero fields
+func (c Config) withDefaults() Config {
+	if c.Runs <= 0 {
+		c.Runs = 100
+	}
+	if c.MaxSize <= 0 {
+		c.MaxSize = 100
+	}
+	if c.MaxShrinks <= 0 {
+		c.MaxShrinks = 1000
+	}
+	if c.Seed == 0 {
+		c.Seed = uint64(time.Now().UnixNano())
+	}
+	return c
+}
+
+// Run tests prop against cfg.Runs generated inputs and returns the first
+// failure, shrunk, or nil if the property held every time
+func Run[T any](cfg Config, gen Gen[T], prop func(T) error) *Failure[T] {
+	cfg = cfg.withDefaults()
+	r := rand.New(rand.NewPCG(cfg.Seed, cfg.Seed))
+
+	for run := 0; run < cfg.Runs; run++ {
+		size := run * cfg.MaxSize / max(cfg.Runs-1, 1)
+		input := gen.Generate(r, size)
+		err := prop(input)
+		if err == nil {
+			continue
+		}
+
+		f := &Failure[T]{Seed: cfg.Seed, Run: run + 1, Original: input, Shrunk: input, Err: err}
+		shrink(cfg, gen, prop, f)
+		return f
+	}
+	return nil
+}
+
+// shrink greedily replaces f.Shrunk with the first simpler candidate that
+// still fails, until no candidate fails or the step budget runs out
+func shrink[T any](cfg Config, gen Gen[T], prop func(T) error, f *Failure[T]) {
+	if gen.Shrink == nil {
+		return
+	}
+
+	for f.Shrinks < cfg.MaxShrinks {
+		progressed := false
+		for _, candidate := range gen.Shrink(f.Shrunk) {
+			if err := prop(candidate); err != nil {
+				f.Shrunk, f.Err = candidate, err
+				f.Shrinks++
+				progressed = true
+				break
+			}
+		}
+		if !progressed {
+			return
+		}
+	}
+}
+
+// Check runs the property with the default configuration and fails t with
+// the shrunk counterexample
+func Check[T any](t testing.TB, gen Gen[T], prop func(T) error) {
+	t.Helper()
+	CheckConfig(t, Config{}, gen, prop)
+}
+
+// CheckConfig is Check with an explicit configuration
+func CheckConfig[T any](t testing.TB, cfg Config, gen Gen[T], prop func(T) error) {
+	t.Helper()
+	if f := Run(cfg, gen, prop); f != nil {
+		t.Fatal(f.Error())
+	}
+}
@@ -0,0 +1,168 @@
+package proptest
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestIntStaysInRange(t *testing.T) {
+	tests := []struct {
+		name   string
+		lo, hi int
+		target int
+	}{
+		{name: "around zero", lo: -50, hi: 50, target: 0},
+		{name: "positive range", lo: 10, hi: 20, target: 10},
+		{name: "negative range", lo: -20, hi: -10, target: -10},
+		{name: "full int range", lo: math.MinInt, hi: math.MaxInt, target: 0},
+		{name: "single value", lo: 7, hi: 7, target: 7},
+	}
+
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := Int(tt.lo, tt.hi)
+			for size := 0; size <= 100; size++ {
+				v := gen.Generate(r, size)
+				if v < tt.lo || v > tt.hi {
+					t.Fatalf("Generate(size %d) = %d, outside [%d, %d]", size, v, tt.lo, tt.hi)
+				}
+				for _, c := range gen.Shrink(v) {
+					if c < tt.lo || c > tt.hi {
+						t.Fatalf("Shrink(%d) proposed %d, outside [%d, %d]", v, c, tt.lo, tt.hi)
+					}
+				}
+			}
+			if got := gen.Shrink(tt.target); len(got) != 0 {
+				t.Errorf("Shrink(%d) = %v, want nothing for the target", tt.target, got)
+			}
+		})
+	}
+}
+
+func TestShrinkInt(t *testing.T) {
+	tests := []struct {
+		v, target int
+		want      []int
+	}{
+		{v: 100, target: 0, want: []int{0, 50, 75, 88, 94, 97, 99}},
+		{v: -9, target: 0, want: []int{0, -5, -7, -8}},
+		{v: 13, target: 10, want: []int{10, 12}},
+		{v: 0, target: 0, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.v), func(t *testing.T) {
+			if got := shrinkInt(tt.v, tt.target); !slices.Equal(got, tt.want) {
+				t.Errorf("shrinkInt(%d, %d) = %v, want %v", tt.v, tt.target, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunPasses(t *testing.T) {
+	runs := 0
+	f := Run(Config{Runs: 50, Seed: 1}, SliceOf(Int(-10, 10)), func(s []int) error {
+		runs++
+		return nil
+	})
+	if f != nil || runs != 50 {
+		t.Errorf("Run() = %v after %d runs, want nil after 50", f, runs)
+	}
+}
+
+func TestRunShrinks(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func() (any, error)
+		want string
+	}{
+		{
+			name: "int shrinks to the boundary",
+			run: func() (any, error) {
+				f := Run(Config{Seed: 3}, Int(-1000, 1000), func(n int) error {
+					if n >= 42 {
+						return errors.New("too big")
+					}
+					return nil
+				})
+				if f == nil {
+					return nil, errors.New("no failure found")
+				}
+				return f.Shrunk, nil
+			},
+			want: "42",
+		},
+		{
+			name: "slice shrinks to the single offending element",
+			run: func() (any, error) {
+				f := Run(Config{Seed: 5}, SliceOf(Int(-1000, 1000)), func(s []int) error {
+					for _, v := range s {
+						if v < -20 {
+							return fmt.Errorf("%d is too small", v)
+						}
+					}
+					return nil
+				})
+				if f == nil {
+					return nil, errors.New("no failure found")
+				}
+				return f.Shrunk, nil
+			},
+			want: "[-21]",
+		},
+		{
+			name: "pair shrinks both sides",
+			run: func() (any, error) {
+				f := Run(Config{Seed: 7}, Zip(Int(0, 1000), Int(0, 1000)), func(p Pair[int, int]) error {
+					if p.First+p.Second > 100 {
+						return errors.New("sum too big")
+					}
+					return nil
+				})
+				if f == nil {
+					return nil, errors.New("no failure found")
+				}
+				return f.Shrunk.First + f.Shrunk.Second, nil
+			},
+			want: "101",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.run()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(got) != tt.want {
+				t.Errorf("shrunk to %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunIsReproducible(t *testing.T) {
+	prop := func(s []int) error {
+		if len(s) > 3 {
+			return errors.New("too long")
+		}
+		return nil
+	}
+	first := Run(Config{Seed: 99}, SliceOf(Int(-5, 5)), prop)
+	second := Run(Config{Seed: 99}, SliceOf(Int(-5, 5)), prop)
+	if first == nil || second == nil {
+		t.Fatal("Run() found no failure")
+	}
+	if first.Run != second.Run || !slices.Equal(first.Original, second.Original) {
+		t.Errorf("runs with the same seed differ: %v vs %v", first, second)
+	}
+	if !strings.Contains(first.Error(), "seed 99") || len(first.Shrunk) != 4 {
+		t.Errorf("Error() = %q, want the seed and a 4-element counterexample", first.Error())
+	}
+}
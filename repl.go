@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+/*----- Extension: Interactive REPL -----*/
+
+// maxHistory bounds how many lines the history file keeps
+const maxHistory = 1000
+
+// lineReader yields one line of input at a time
+type lineReader interface {
+	ReadLine() (string, error)
+}
+
+// scannerReader reads lines from a non-interactive input such as a pipe
+type scannerReader struct {
+	scanner *bufio.Scanner
+}
+
+// ReadLine returns the next line or io.EOF
+func (r scannerReader) ReadLine() (string, error) {
+	if !r.scanner.Scan() {
+		if err := r.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+	return r.scanner.Text(), nil
+}
+
+// fileHistory is a term.History that also appends every entry to a file
+type fileHistory struct {
+	path    string
+	entries []string // oldest first
+}
+
+// loadHistory reads previous sessions' lines from path, if it exists
+func loadHistory(path string) (*fileHistory, error) {
+	h := &fileHistory{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if line != "" {
+			h.entries = append(h.entries, line)
+		}
+	}
+	if len(h.entries) > maxHistory {
+		h.entries = h.entries[len(h.entries)-maxHistory:]
+	}
+	return h, nil
+}
+
+// Add records a line in memory and appends it to the history file
+// A failed write only loses history, so it is deliberately ignored.
+func (h *fileHistory) Add(entry string) {
+	if entry == "" {
+		return
+	}
+	h.entries = append(h.entries, entry)
+	if len(h.entries) > maxHistory {
+		h.entries = h.entries[1:]
+	}
+
+	f, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	fmt.Fprintln(f, entry)
+}
+
+// Len returns the number of remembered lines
+func (h *fileHistory) Len() int {
+	return len(h.entries)
+}
+
+// At returns the idx-th most recent line, 0 being the newest
+func (h *fileHistory) At(idx int) string {
+	return h.entries[len(h.entries)-1-idx]
+}
+
+// defaultHistoryPath returns ~/.analyzer_history, or "" if there is no home
+func defaultHistoryPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".analyzer_history")
+}
+
+// runREPL handles `analyzer repl [--history <file>]`
+func runREPL(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("repl", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	historyPath := flags.String("history", defaultHistoryPath(), "file that stores input history")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if flags.NArg() != 0 {
+		return fmt.Errorf("%w: unexpected argument %q", errUsage, flags.Arg(0))
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return replLoop(scannerReader{bufio.NewScanner(os.Stdin)}, stdout)
+	}
+
+	// Raw mode lets term.Terminal handle arrow keys, editing and history
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer term.Restore(fd, state)
+
+	terminal := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{os.Stdin, stdout}, "> ")
+	if *historyPath != "" {
+		history, err := loadHistory(*historyPath)
+		if err != nil {
+			return err
+		}
+		terminal.History = history
+	}
+
+	fmt.Fprintln(terminal, "analyzer repl - type :help for help, :quit or Ctrl-D to exit")
+	return replLoop(terminal, terminal)
+}
+
+// replLoop evaluates lines until :quit or end of input
+// Bindings live for the whole session, so closures keep their state.
+func replLoop(lines lineReader, out io.Writer) error {
+	env := newEvalEnv(nil)
+	for {
+		line, err := lines.ReadLine()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		line = strings.TrimSpace(line)
+		switch line {
+		case "":
+			continue
+		case ":quit", ":q":
+			return nil
+		case ":help":
+			printREPLHelp(out)
+			continue
+		case ":vars":
+			printBindings(out, env)
+			continue
+		}
+
+		n, err := parseExpr(line)
+		if err == nil {
+			var result value
+			if result, err = n.eval(env); err == nil && result != nil {
+				fmt.Fprintln(out, FormatValue(result))
+			}
+		}
+		if err != nil {
+			fmt.Fprintln(out, "error:", err)
+		}
+	}
+}
+
+// printBindings lists the session's variables in alphabetical order
+func printBindings(out io.Writer, env *evalEnv) {
+	names := make([]string, 0, len(env.vars))
+	for name := range env.vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(out, "%s = %s\n", name, FormatValue(env.vars[name]))
+	}
+}
+
+// printREPLHelp describes the REPL-only features on top of eval
+func printREPLHelp(out io.Writer) {
+	fmt.Fprintln(out, "Enter any eval expression, or bind names with =:")
+	fmt.Fprintln(out, "  c = counter(0)                    c() returns 1, 2, 3, ...")
+	fmt.Fprintln(out, "  triple = multiplier(3)            triple(7) returns 21")
+	fmt.Fprintln(out, "  add, sub, get = accumulator(50)   add(25) then get() returns 75")
+	fmt.Fprintln(out, "  xs = filter(isprime, 1..50)       map(x*2, xs)")
+	fmt.Fprintln(out, "Commands: :vars lists bindings, :help shows this, :quit exits")
+}
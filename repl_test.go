@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+/*----- Extension: Interactive REPL -----*/
+
+// fakeLines feeds a fixed script to replLoop
+type fakeLines []string
+
+// ReadLine pops the next scripted line
+func (f *fakeLines) ReadLine() (string, error) {
+	if len(*f) == 0 {
+		return "", io.EOF
+	}
+	line := (*f)[0]
+	*f = (*f)[1:]
+	return line, nil
+}
+
+// TestReplLoop runs scripted sessions and compares the printed output
+func TestReplLoop(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  string
+	}{
+		{
+			name:  "counter keeps state between lines",
+			lines: []string{"c = counter(0)", "c()", "c()", "c()"},
+			want:  "1\n2\n3\n",
+		},
+		{
+			name:  "counters are independent",
+			lines: []string{"a = counter(0)", "b = counter(100)", "a()", "b()", "a()"},
+			want:  "1\n101\n2\n",
+		},
+		{
+			name:  "accumulator closures share state",
+			lines: []string{"add, sub, get = accumulator(50)", "add(25)", "sub(15)", "get()", "add(40)", "get()"},
+			want:  "60\n100\n",
+		},
+		{
+			name:  "multiplier",
+			lines: []string{"triple = multiplier(3)", "triple(7)", "map(triple, 1..3)"},
+			want:  "21\n[3 6 9]\n",
+		},
+		{
+			name:  "list variables",
+			lines: []string{"xs = filter(isprime, 1..10)", "xs | reduce(+)"},
+			want:  "17\n",
+		},
+		{
+			name:  "errors do not end the session",
+			lines: []string{"factorial(-1)", "nope", "1 + 1"},
+			want:  "error: factorial is not defined for negative numbers\nerror: undefined name \"nope\"\n2\n",
+		},
+		{
+			name:  "bad destructuring",
+			lines: []string{"a, b = accumulator(1)", "c = accumulator(1)"},
+			want:  "error: cannot assign (<function add>, <function subtract>, <function get>) to 2 names\nerror: cannot assign (<function add>, <function subtract>, <function get>) to a single name\n",
+		},
+		{
+			name:  "vars lists bindings",
+			lines: []string{"n = 3", "c = counter(n)", ":vars"},
+			want:  "c = <function counter>\nn = 3\n",
+		},
+		{
+			name:  "quit stops reading",
+			lines: []string{"1", ":quit", "2"},
+			want:  "1\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := fakeLines(tt.lines)
+			var out bytes.Buffer
+			if err := replLoop(&lines, &out); err != nil {
+				t.Fatalf("replLoop() error = %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("replLoop() output = %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+// TestFileHistory tests loading, bounding and appending history lines
+func TestFileHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history")
+
+	t.Run("missing file starts empty", func(t *testing.T) {
+		h, err := loadHistory(path)
+		if err != nil {
+			t.Fatalf("loadHistory() error = %v", err)
+		}
+		if h.Len() != 0 {
+			t.Errorf("Len() = %d, want 0", h.Len())
+		}
+	})
+
+	t.Run("entries persist across sessions", func(t *testing.T) {
+		h, _ := loadHistory(path)
+		h.Add("c = counter(0)")
+		h.Add("")
+		h.Add("c()")
+		if h.Len() != 2 || h.At(0) != "c()" || h.At(1) != "c = counter(0)" {
+			t.Errorf("history = %q, want newest-first [c() c = counter(0)]", h.entries)
+		}
+
+		reloaded, err := loadHistory(path)
+		if err != nil {
+			t.Fatalf("loadHistory() error = %v", err)
+		}
+		if reloaded.Len() != 2 || reloaded.At(0) != "c()" {
+			t.Errorf("reloaded history = %q, want the two saved lines", reloaded.entries)
+		}
+	})
+
+	t.Run("history is bounded", func(t *testing.T) {
+		long := filepath.Join(t.TempDir(), "history")
+		data := strings.Repeat("1 + 1\n", maxHistory+10)
+		if err := os.WriteFile(long, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		h, _ := loadHistory(long)
+		if h.Len() != maxHistory {
+			t.Errorf("Len() = %d, want %d", h.Len(), maxHistory)
+		}
+	})
+}
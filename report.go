@@ -0,0 +1,193 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+)
+
+/*----- Extension: Structured Demo Output -----*/
+
+// demoRecord is one observation from the demo, e.g. a single function call
+// text is how the plain demo prints it, including any prose before it.
+type demoRecord struct {
+	Section string `json:"section"`
+	Name    string `json:"name"`
+	Inputs  []any  `json:"inputs"`
+	Output  any    `json:"output,omitempty"`
+	Error   string `json:"error,omitempty"`
+	text    string
+}
+
+// demoSections lists the demo sections in the order main prints them
+// main prints each section's records with writeDemoText, and --format
+// renders the very same records as data, so the two cannot drift apart.
+var demoSections = []struct {
+	name    string
+	records func() []demoRecord
+}{
+	{name: "process", records: processRecords},
+	{name: "math", records: mathRecords},
+	{name: "closures", records: closureRecords},
+	{name: "higher-order", records: higherOrderRecords},
+	{name: "pointers", records: pointerRecords},
+}
+
+// demoBuilder collects one section's records along with the prose the
+// plain demo prints between them
+// The zero value is ready to use.
+type demoBuilder struct {
+	records []demoRecord
+	prose   string
+}
+
+// say adds a line of prose, formatted like fmt.Println, before the next record
+func (b *demoBuilder) say(args ...any) {
+	b.prose += fmt.Sprintln(args...)
+}
+
+// add appends r, printed in the plain demo as line (formatted like fmt.Println)
+func (b *demoBuilder) add(r demoRecord, line ...any) {
+	r.text = b.prose + fmt.Sprintln(line...)
+	b.prose = ""
+	b.records = append(b.records, r)
+}
+
+// addResult appends r with the demo's "call = result" or "call error: ..." line
+func (b *demoBuilder) addResult(r demoRecord, call ...any) {
+	if r.Error != "" {
+		b.add(r, append(call, "error:", r.Error)...)
+		return
+	}
+	b.add(r, append(call, "=", r.Output)...)
+}
+
+// done returns the records, attaching any trailing prose to the last one
+func (b *demoBuilder) done() []demoRecord {
+	if n := len(b.records); n > 0 {
+		b.records[n-1].text += b.prose
+	}
+	return b.records
+}
+
+// writeDemoText prints records the way the plain demo does
+func writeDemoText(w io.Writer, records []demoRecord) {
+	for _, r := range records {
+		fmt.Fprint(w, r.text)
+	}
+}
+
+// demoFormats maps each --format value to its writer
+var demoFormats = map[string]func(w io.Writer, records []demoRecord) error{
+	"text": writeText,
+	"json": writeJSON,
+	"yaml": writeYAML,
+}
+
+// runDemoReport handles `analyzer --format json|text|yaml`
+func runDemoReport(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("analyzer", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	format := flags.String("format", "text", "output format: json, text or yaml")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if flags.NArg() != 0 {
+		return fmt.Errorf("%w: unexpected argument %q", errUsage, flags.Arg(0))
+	}
+
+	write, ok := demoFormats[*format]
+	if !ok {
+		return fmt.Errorf("%w: unknown format %q (want json, text or yaml)", errUsage, *format)
+	}
+
+	var records []demoRecord
+	for _, section := range demoSections {
+		records = append(records, section.records()...)
+	}
+	return write(stdout, records)
+}
+
+// record builds a demoRecord from a call's inputs, result and error
+func record(section, name string, output any, err error, inputs ...any) demoRecord {
+	r := demoRecord{Section: section, Name: name, Inputs: inputs, Output: output}
+	if r.Inputs == nil {
+		r.Inputs = []any{}
+	}
+	if err != nil {
+		r.Output = nil
+		r.Error = err.Error()
+	}
+	return r
+}
+
+// writeText renders one line per record, grouped under section headings
+func writeText(w io.Writer, records []demoRecord) error {
+	section := ""
+	for _, r := range records {
+		if r.Section != section {
+			if section != "" {
+				fmt.Fprintln(w)
+			}
+			section = r.Section
+			fmt.Fprintf(w, "=== %s ===\n", section)
+		}
+
+		call := r.Name
+		if len(r.Inputs) > 0 {
+			inputs := make([]string, len(r.Inputs))
+			for i, in := range r.Inputs {
+				inputs[i] = fmt.Sprint(in)
+			}
+			call = fmt.Sprintf("%s(%s)", r.Name, strings.Join(inputs, ", "))
+		}
+
+		if r.Error != "" {
+			fmt.Fprintf(w, "%s error: %s\n", call, r.Error)
+		} else {
+			fmt.Fprintf(w, "%s = %v\n", call, r.Output)
+		}
+	}
+	return nil
+}
+
+// writeJSON renders the records as a single indented JSON array
+func writeJSON(w io.Writer, records []demoRecord) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(records)
+}
+
+// writeYAML renders the records as a YAML sequence
+// Values are written in JSON syntax, which YAML 1.2 accepts as flow style.
+func writeYAML(w io.Writer, records []demoRecord) error {
+	for _, r := range records {
+		fields := []struct {
+			key   string
+			value any
+			skip  bool
+		}{
+			{key: "section", value: r.Section},
+			{key: "name", value: r.Name},
+			{key: "inputs", value: r.Inputs},
+			{key: "output", value: r.Output, skip: r.Output == nil},
+			{key: "error", value: r.Error, skip: r.Error == ""},
+		}
+
+		prefix := "- "
+		for _, f := range fields {
+			if f.skip {
+				continue
+			}
+			value, err := json.Marshal(f.value)
+			if err != nil {
+				return fmt.Errorf("yaml field %s: %w", f.key, err)
+			}
+			fmt.Fprintf(w, "%s%s: %s\n", prefix, f.key, value)
+			prefix = "  "
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+/*----- Extension: Structured Demo Output -----*/
+
+// TestDemoSections checks that every section produces well-formed records
+func TestDemoSections(t *testing.T) {
+	for _, section := range demoSections {
+		t.Run(section.name, func(t *testing.T) {
+			records := section.records()
+			if len(records) == 0 {
+				t.Fatalf("%s produced no records", section.name)
+			}
+			for _, r := range records {
+				if r.Section != section.name {
+					t.Errorf("record %q has section %q, want %q", r.Name, r.Section, section.name)
+				}
+				if r.Inputs == nil {
+					t.Errorf("record %q has nil inputs, want an empty list", r.Name)
+				}
+			}
+		})
+	}
+}
+
+// TestRecord checks that errors replace outputs
+func TestRecord(t *testing.T) {
+	result, err := Factorial(-1)
+	r := record("math", "Factorial", result, err, -1)
+	if r.Output != nil {
+		t.Errorf("record() output = %v, want nil when an error is present", r.Output)
+	}
+	if r.Error != "factorial is not defined for negative numbers" {
+		t.Errorf("record() error = %q", r.Error)
+	}
+}
+
+// TestDemoBuilder checks that prose and result lines land in record order
+func TestDemoBuilder(t *testing.T) {
+	var section demoBuilder
+	section.say("=== Demo ===")
+	result, err := Factorial(3)
+	section.addResult(record("demo", "Factorial", result, err, 3), "Factorial(", 3, ")")
+	result, err = Factorial(-1)
+	section.addResult(record("demo", "Factorial", result, err, -1), "Factorial(", -1, ")")
+	section.say("Done")
+	records := section.done()
+
+	if len(records) != 2 {
+		t.Fatalf("done() returned %d records, want 2", len(records))
+	}
+	var buf bytes.Buffer
+	writeDemoText(&buf, records)
+	want := "=== Demo ===\nFactorial( 3 ) = 6\nFactorial( -1 ) error: factorial is not defined for negative numbers\nDone\n"
+	if buf.String() != want {
+		t.Errorf("writeDemoText() = %q, want %q", buf.String(), want)
+	}
+}
+
+// TestDemoFormats runs the demo through every output format
+func TestDemoFormats(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		var stdout bytes.Buffer
+		if err := runDemoReport([]string{"--format", "json"}, &stdout); err != nil {
+			t.Fatalf("runDemoReport() error = %v", err)
+		}
+
+		var records []demoRecord
+		if err := json.Unmarshal(stdout.Bytes(), &records); err != nil {
+			t.Fatalf("output is not valid JSON: %v", err)
+		}
+		// JSON numbers decode into float64 when the target is any
+		found := false
+		for _, r := range records {
+			if r.Name == "Factorial" && len(r.Inputs) == 1 && r.Inputs[0] == float64(5) {
+				found = r.Output == float64(120)
+			}
+		}
+		if !found {
+			t.Errorf("JSON output is missing Factorial(5) = 120")
+		}
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		var stdout bytes.Buffer
+		if err := runDemoReport([]string{"--format=yaml"}, &stdout); err != nil {
+			t.Fatalf("runDemoReport() error = %v", err)
+		}
+		want := "- section: \"math\"\n  name: \"Power\"\n  inputs: [2,8]\n  output: 256\n"
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("YAML output is missing %q", want)
+		}
+	})
+
+	t.Run("text", func(t *testing.T) {
+		var stdout bytes.Buffer
+		if err := runDemoReport([]string{"--format", "text"}, &stdout); err != nil {
+			t.Fatalf("runDemoReport() error = %v", err)
+		}
+		for _, want := range []string{"=== pointers ===", "IsPrime(17) = true", "SwapValues(5, 10) = [10 5]"} {
+			if !strings.Contains(stdout.String(), want) {
+				t.Errorf("text output is missing %q", want)
+			}
+		}
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		err := runDemoReport([]string{"--format", "xml"}, &bytes.Buffer{})
+		if !errors.Is(err, errUsage) {
+			t.Errorf("runDemoReport() error = %v, want a usage error", err)
+		}
+	})
+
+	t.Run("dispatched from run", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		if code := run([]string{"--format", "json"}, &stdout, &stderr); code != exitOK {
+			t.Errorf("run(--format json) exit code = %d, want %d (stderr: %s)", code, exitOK, stderr.String())
+		}
+		if code := run([]string{"--format", "xml"}, &stdout, &stderr); code != exitUsage {
+			t.Errorf("run(--format xml) exit code = %d, want %d", code, exitUsage)
+		}
+	})
+}
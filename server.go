@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+)
+
+/*----- Extension: HTTP JSON API -----*/
+
+const (
+	// maxRequestBytes caps request bodies; every valid request is tiny
+	maxRequestBytes = 64 << 10
+
+	// maxPrimesLimit keeps /primes responses to a few megabytes
+	maxPrimesLimit = 10_000_000
+
+	// maxPrimeCheck keeps one /isprime call to about 16 million trial
+	// divisions; IsPrime near 2^63 would need 1.5 billion
+	maxPrimeCheck int64 = 1_000_000_000_000_000
+
+	// maxEvalSteps bounds the work of one /eval expression (see
+	// EvalExprContext), and maxEvalTime bounds its wall-clock time
+	maxEvalSteps = 20_000_000
+	maxEvalTime  = 5 * time.Second
+)
+
+// evalLimits bounds the steps and wall-clock time of one expression
+type evalLimits struct {
+	steps   int
+	timeout time.Duration
+}
+
+// defaultEvalLimits are the limits the servers apply to every expression
+var defaultEvalLimits = evalLimits{steps: maxEvalSteps, timeout: maxEvalTime}
+
+// eval runs EvalExprContext on src within the limits
+func (l evalLimits) eval(ctx context.Context, src string) (any, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.timeout)
+	defer cancel()
+	return EvalExprContext(ctx, src, l.steps)
+}
+
+// Request and response schemas. Pointer fields distinguish a missing field
+// from an explicit zero.
+type (
+	numberRequest struct {
+		N *int `json:"n"`
+	}
+	powerRequest struct {
+		Base     *int `json:"base"`
+		Exponent *int `json:"exponent"`
+	}
+	primesRequest struct {
+		UpTo *int `json:"upto"`
+	}
+	evalRequest struct {
+		Expression string `json:"expression"`
+	}
+	resultResponse struct {
+		Result any `json:"result"`
+	}
+	errorResponse struct {
+		Error string `json:"error"`
+	}
+)
+
+// newAPIHandler routes every endpoint of the JSON API, evaluating /eval
+// expressions within limits
+func newAPIHandler(limits evalLimits) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /factorial", jsonHandler(func(_ context.Context, req numberRequest) (any, error) {
+		if req.N == nil {
+			return nil, missingField("n")
+		}
+		return checkedFactorial(*req.N)
+	}))
+	mux.HandleFunc("POST /isprime", jsonHandler(func(_ context.Context, req numberRequest) (any, error) {
+		if req.N == nil {
+			return nil, missingField("n")
+		}
+		if int64(*req.N) > maxPrimeCheck {
+			return nil, fmt.Errorf("n must be at most %d", maxPrimeCheck)
+		}
+		return IsPrime(*req.N)
+	}))
+	mux.HandleFunc("POST /power", jsonHandler(func(_ context.Context, req powerRequest) (any, error) {
+		if req.Base == nil {
+			return nil, missingField("base")
+		}
+		if req.Exponent == nil {
+			return nil, missingField("exponent")
+		}
+		return checkedPower(*req.Base, *req.Exponent)
+	}))
+	mux.HandleFunc("POST /primes", jsonHandler(func(_ context.Context, req primesRequest) (any, error) {
+		if req.UpTo == nil {
+			return nil, missingField("upto")
+		}
+		if *req.UpTo > maxPrimesLimit {
+			return nil, fmt.Errorf("upto must be at most %d", maxPrimesLimit)
+		}
+		return PrimesUpTo(*req.UpTo)
+	}))
+	mux.HandleFunc("POST /eval", jsonHandler(func(ctx context.Context, req evalRequest) (any, error) {
+		if req.Expression == "" {
+			return nil, missingField("expression")
+		}
+		result, err := limits.eval(ctx, req.Expression)
+		if err != nil {
+			return nil, err
+		}
+		switch result.(type) {
+		case int, bool, []int:
+			return result, nil
+		}
+		return nil, fmt.Errorf("expression must evaluate to a number, boolean or list, got %s", FormatValue(result))
+	}))
+	mux.HandleFunc("GET /process", func(w http.ResponseWriter, r *http.Request) {
+		info, err := GetProcessInfo()
+		if err != nil {
+			respondJSON(w, http.StatusInternalServerError, errorResponse{Error: err.Error()})
+			return
+		}
+		respondJSON(w, http.StatusOK, info)
+	})
+	return mux
+}
+
+// jsonHandler decodes a request of type Req, calls fn and encodes the result
+// Errors fn returns come from invalid input and map to 400, except running
+// out of time, which maps to 503.
+func jsonHandler[Req any](fn func(ctx context.Context, req Req) (any, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, maxRequestBytes)
+
+		var req Req
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&req); err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				respondJSON(w, http.StatusRequestEntityTooLarge, errorResponse{Error: "request body too large"})
+				return
+			}
+			respondJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid JSON: " + err.Error()})
+			return
+		}
+		if dec.More() {
+			respondJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid JSON: unexpected data after object"})
+			return
+		}
+
+		result, err := fn(r.Context(), req)
+		if errors.Is(err, context.DeadlineExceeded) {
+			respondJSON(w, http.StatusServiceUnavailable, errorResponse{Error: "request took too long"})
+			return
+		}
+		if err != nil {
+			respondJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+			return
+		}
+		respondJSON(w, http.StatusOK, resultResponse{Result: result})
+	}
+}
+
+// respondJSON writes v as the JSON response body with the given status
+func respondJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.Encode(v)
+}
+
+// missingField reports a required request field that was not sent
+func missingField(name string) error {
+	return fmt.Errorf("missing field %q", name)
+}
+
+// runServe handles `analyzer serve [--addr :8080]`, stopping on Ctrl-C
+func runServe(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	addr := flags.String("addr", "localhost:8080", "address to listen on")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if flags.NArg() != 0 {
+		return fmt.Errorf("%w: unexpected argument %q", errUsage, flags.Arg(0))
+	}
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           newAPIHandler(defaultEvalLimits),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      30 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintln(stdout, "analyzer: serving on", *addr)
+	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+/*----- Extension: HTTP JSON API -----*/
+
+// TestAPIHandler exercises every endpoint through an httptest server
+// The step budget is small and the deadline long, so the step limit case
+// fails on steps however slowly the tests run.
+func TestAPIHandler(t *testing.T) {
+	server := httptest.NewServer(newAPIHandler(evalLimits{steps: 100_000, timeout: time.Minute}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+		needs64    bool
+	}{
+		// Successful calls
+		{name: "factorial", method: "POST", path: "/factorial", body: `{"n": 5}`, wantStatus: 200, wantBody: `{"result":120}`},
+		{name: "factorial of zero", method: "POST", path: "/factorial", body: `{"n": 0}`, wantStatus: 200, wantBody: `{"result":1}`},
+		{name: "isprime", method: "POST", path: "/isprime", body: `{"n": 97}`, wantStatus: 200, wantBody: `{"result":true}`},
+		{name: "power", method: "POST", path: "/power", body: `{"base": 2, "exponent": 10}`, wantStatus: 200, wantBody: `{"result":1024}`},
+		{name: "primes", method: "POST", path: "/primes", body: `{"upto": 20}`, wantStatus: 200, wantBody: `{"result":[2,3,5,7,11,13,17,19]}`},
+		{name: "eval", method: "POST", path: "/eval", body: `{"expression": "map(x*x, 1..4) | reduce(+)"}`, wantStatus: 200, wantBody: `{"result":30}`},
+
+		// Library errors map to 400
+		{name: "negative factorial", method: "POST", path: "/factorial", body: `{"n": -1}`, wantStatus: 400, wantBody: `{"error":"factorial is not defined for negative numbers"}`},
+		{name: "factorial overflow", method: "POST", path: "/factorial", body: `{"n": 21}`, wantStatus: 400, wantBody: `{"error":"factorial result overflows int"}`},
+		{name: "isprime below 2", method: "POST", path: "/isprime", body: `{"n": 1}`, wantStatus: 400, wantBody: `{"error":"prime check requires number >= 2"}`},
+		{name: "negative exponent", method: "POST", path: "/power", body: `{"base": 2, "exponent": -1}`, wantStatus: 400, wantBody: `{"error":"negative exponents not supported"}`},
+		{name: "eval error", method: "POST", path: "/eval", body: `{"expression": "1 / 0"}`, wantStatus: 400, wantBody: `{"error":"division by zero"}`},
+		{name: "eval returning a function", method: "POST", path: "/eval", body: `{"expression": "counter(0)"}`, wantStatus: 400, wantBody: `{"error":"expression must evaluate to a number, boolean or list, got <function counter>"}`},
+		{name: "isprime limit", method: "POST", path: "/isprime", body: `{"n": 9223372036854775783}`, wantStatus: 400, wantBody: `{"error":"n must be at most 1000000000000000"}`, needs64: true},
+		{name: "eval step limit", method: "POST", path: "/eval", body: `{"expression": "map(reduce(+, 1..x), 1..1000000)"}`, wantStatus: 400, wantBody: `{"error":"expression exceeds the evaluation step limit"}`},
+		{name: "primes limit", method: "POST", path: "/primes", body: `{"upto": 1000000000}`, wantStatus: 400, wantBody: `{"error":"upto must be at most 10000000"}`},
+
+		// Input validation
+		{name: "missing field", method: "POST", path: "/power", body: `{"base": 2}`, wantStatus: 400, wantBody: `{"error":"missing field \"exponent\""}`},
+		{name: "unknown field", method: "POST", path: "/factorial", body: `{"m": 2}`, wantStatus: 400, wantBody: `{"error":"invalid JSON: json: unknown field \"m\""}`},
+		{name: "wrong type", method: "POST", path: "/factorial", body: `{"n": "five"}`, wantStatus: 400},
+		{name: "malformed JSON", method: "POST", path: "/factorial", body: `{"n":`, wantStatus: 400},
+		{name: "trailing data", method: "POST", path: "/factorial", body: `{"n": 1} {"n": 2}`, wantStatus: 400},
+		{name: "body too large", method: "POST", path: "/eval", body: `{"expression": "` + strings.Repeat("1+", maxRequestBytes) + `1"}`, wantStatus: 413},
+		{name: "wrong method", method: "GET", path: "/factorial", wantStatus: 405},
+		{name: "process info", method: "GET", path: "/process", wantStatus: 200},
+		{name: "unknown endpoint", method: "POST", path: "/sqrt", body: `{"n": 4}`, wantStatus: 404},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.needs64 && strconv.IntSize < 64 {
+				t.Skip("request does not fit in a 32-bit int")
+			}
+			req, err := http.NewRequest(tt.method, server.URL+tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("%s %s status = %d, want %d", tt.method, tt.path, resp.StatusCode, tt.wantStatus)
+			}
+			if tt.wantBody == "" {
+				return
+			}
+
+			var got json.RawMessage
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatalf("response is not JSON: %v", err)
+			}
+			if string(got) != tt.wantBody {
+				t.Errorf("%s %s body = %s, want %s", tt.method, tt.path, got, tt.wantBody)
+			}
+			if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", ct)
+			}
+		})
+	}
+}
+
+// TestAPIHandlerEvalTimeout tests that an expression still running at its
+// deadline is cut off with 503
+func TestAPIHandlerEvalTimeout(t *testing.T) {
+	server := httptest.NewServer(newAPIHandler(evalLimits{steps: maxEvalSteps, timeout: time.Nanosecond}))
+	defer server.Close()
+
+	body := `{"expression": "map(reduce(+, 1..x), 1..1000)"}`
+	resp, err := http.Post(server.URL+"/eval", "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("POST /eval status = %d, want %d", resp.StatusCode, http.StatusServiceUnavailable)
+	}
+	var got errorResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("response is not JSON: %v", err)
+	}
+	if got.Error != "request took too long" {
+		t.Errorf("POST /eval error = %q, want %q", got.Error, "request took too long")
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"unsafe"
+)
+
+/*----- Extension: Slice Header Introspection -----*/
+
+// SliceInfo is the content of a slice header plus its element size
+type SliceInfo struct {
+	Data     uintptr // address of the first element of the backing array
+	Len      int
+	Cap      int
+	ElemSize uintptr
+}
+
+// String formats the header like "data=0xc000010000 len=3 cap=4 elem=8B"
+func (s SliceInfo) String() string {
+	return fmt.Sprintf("data=%#x len=%d cap=%d elem=%dB", s.Data, s.Len, s.Cap, s.ElemSize)
+}
+
+// 1. InspectSlice - reads the header of s
+func InspectSlice[T any](s []T) SliceInfo {
+	var zero T
+	return SliceInfo{
+		Data:     uintptr(unsafe.Pointer(unsafe.SliceData(s))),
+		Len:      len(s),
+		Cap:      cap(s),
+		ElemSize: unsafe.Sizeof(zero),
+	}
+}
+
+// 2. SlicesOverlap - reports whether a and b have at least one element in
+// common, so writing through one changes what the other sees
+func SlicesOverlap[T any](a, b []T) bool {
+	ia, ib := InspectSlice(a), InspectSlice(b)
+	return rangesIntersect(ia.Data, ia.Len, ib.Data, ib.Len, ia.ElemSize)
+}
+
+// 3. ShareBackingArray - reports whether a and b use the same backing
+// array, counting spare capacity: append on one may then overwrite the other
+func ShareBackingArray[T any](a, b []T) bool {
+	ia, ib := InspectSlice(a), InspectSlice(b)
+	return rangesIntersect(ia.Data, ia.Cap, ib.Data, ib.Cap, ia.ElemSize)
+}
+
+// rangesIntersect reports whether [a, a+aLen*size) and [b, b+bLen*size)
+// share any byte; zero-sized elements never occupy memory
+func rangesIntersect(a uintptr, aLen int, b uintptr, bLen int, size uintptr) bool {
+	if aLen == 0 || bLen == 0 || size == 0 {
+		return false
+	}
+	aEnd := a + uintptr(aLen)*size
+	bEnd := b + uintptr(bLen)*size
+	return a < bEnd && b < aEnd
+}
+
+// AppendStep records the slice header after one append
+type AppendStep struct {
+	Len         int
+	Cap         int
+	Data        uintptr
+	Reallocated bool // the backing array moved to a new address
+}
+
+// 4. TraceAppend - appends values to s one at a time and records each
+// header, showing when append has to reallocate
+func TraceAppend[T any](s []T, values ...T) []AppendStep {
+	steps := make([]AppendStep, 0, len(values))
+	previous := InspectSlice(s).Data
+	for _, v := range values {
+		s = append(s, v)
+		info := InspectSlice(s)
+		steps = append(steps, AppendStep{
+			Len:         info.Len,
+			Cap:         info.Cap,
+			Data:        info.Data,
+			Reallocated: info.Data != previous,
+		})
+		previous = info.Data
+	}
+	return steps
+}
+
+// 5. FormatAppendTrace - draws each step as a bar of used (#) and spare (.)
+// capacity, marking the appends that reallocated
+func FormatAppendTrace(steps []AppendStep) string {
+	var b strings.Builder
+	for _, step := range steps {
+		bar := strings.Repeat("#", step.Len) + strings.Repeat(".", step.Cap-step.Len)
+		note := ""
+		if step.Reallocated {
+			note = "  <- reallocated"
+		}
+		fmt.Fprintf(&b, "len=%-3d cap=%-3d %#x [%s]%s\n", step.Len, step.Cap, step.Data, bar, note)
+	}
+	return b.String()
+}
+
+// runSlices handles `analyzer slices [--appends <n>]`
+func runSlices(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("slices", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	appends := flags.Int("appends", 20, "number of ints to append to a nil slice")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if flags.NArg() != 0 || *appends < 0 || *appends > 1000 {
+		return fmt.Errorf("%w: --appends must be between 0 and 1000", errUsage)
+	}
+
+	values := make([]int, *appends)
+	for i := range values {
+		values[i] = i + 1
+	}
+	fmt.Fprint(stdout, FormatAppendTrace(TraceAppend([]int(nil), values...)))
+	return nil
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+/*----- Extension: Slice Header Introspection -----*/
+
+// 1. InspectSlice
+func TestInspectSlice(t *testing.T) {
+	backing := make([]int64, 5, 8)
+
+	tests := []struct {
+		name     string
+		s        []int64
+		wantData uintptr
+		wantLen  int
+		wantCap  int
+	}{
+		{name: "whole slice", s: backing, wantData: uintptr(unsafe.Pointer(&backing[0])), wantLen: 5, wantCap: 8},
+		{name: "resliced from 2", s: backing[2:4], wantData: uintptr(unsafe.Pointer(&backing[2])), wantLen: 2, wantCap: 6},
+		{name: "full slice expression", s: backing[1:3:3], wantData: uintptr(unsafe.Pointer(&backing[1])), wantLen: 2, wantCap: 2},
+		{name: "nil slice", s: nil, wantData: 0, wantLen: 0, wantCap: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := InspectSlice(tt.s)
+			if got.Data != tt.wantData || got.Len != tt.wantLen || got.Cap != tt.wantCap || got.ElemSize != 8 {
+				t.Errorf("InspectSlice() = %v, want data=%#x len=%d cap=%d elem=8B", got, tt.wantData, tt.wantLen, tt.wantCap)
+			}
+		})
+	}
+
+	if size := InspectSlice([]struct{ a, b int32 }{}).ElemSize; size != 8 {
+		t.Errorf("InspectSlice() ElemSize of a struct = %d, want 8", size)
+	}
+}
+
+// 2. SlicesOverlap & 3. ShareBackingArray
+func TestSliceAliasing(t *testing.T) {
+	a := make([]int, 4, 8)
+	other := make([]int, 4)
+
+	tests := []struct {
+		name        string
+		x, y        []int
+		wantOverlap bool
+		wantShare   bool
+	}{
+		{name: "same slice", x: a, y: a, wantOverlap: true, wantShare: true},
+		{name: "overlapping windows", x: a[0:3], y: a[2:4], wantOverlap: true, wantShare: true},
+		{name: "adjacent windows share spare capacity", x: a[0:2], y: a[2:4], wantOverlap: false, wantShare: true},
+		{name: "capped window cannot reach the next", x: a[0:2:2], y: a[2:4], wantOverlap: false, wantShare: false},
+		{name: "different arrays", x: a, y: other, wantOverlap: false, wantShare: false},
+		{name: "empty slice", x: a[:0], y: a, wantOverlap: false, wantShare: true},
+		{name: "nil slice", x: nil, y: a, wantOverlap: false, wantShare: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SlicesOverlap(tt.x, tt.y); got != tt.wantOverlap {
+				t.Errorf("SlicesOverlap() = %v, want %v", got, tt.wantOverlap)
+			}
+			if got := ShareBackingArray(tt.x, tt.y); got != tt.wantShare {
+				t.Errorf("ShareBackingArray() = %v, want %v", got, tt.wantShare)
+			}
+		})
+	}
+}
+
+// The higher-order functions must never hand back the caller's array
+func TestHigherOrderResultsDoNotAlias(t *testing.T) {
+	nums := []int{1, 2, 3, 4, 5, 6}
+	identity := func(x int) int { return x }
+
+	results := map[string][]int{
+		"Apply":    Apply(nums, identity),
+		"Filter":   Filter(nums, func(int) bool { return true }),
+		"Pipeline": Pipeline(nums, identity),
+	}
+	for name, result := range results {
+		if ShareBackingArray(nums, result) {
+			t.Errorf("%s() result shares its backing array with the input", name)
+		}
+	}
+}
+
+// 4. TraceAppend & 5. FormatAppendTrace
+func TestTraceAppend(t *testing.T) {
+	t.Run("appending within capacity never reallocates", func(t *testing.T) {
+		s := make([]int, 0, 4)
+		for i, step := range TraceAppend(s, 1, 2, 3, 4) {
+			if step.Reallocated || step.Cap != 4 || step.Len != i+1 {
+				t.Errorf("step %d = %+v, want len %d cap 4 without reallocation", i, step, i+1)
+			}
+		}
+	})
+
+	t.Run("exceeding capacity reallocates and grows", func(t *testing.T) {
+		s := make([]int, 2, 2)
+		steps := TraceAppend(s, 3, 4, 5)
+		if !steps[0].Reallocated || steps[0].Cap <= 2 {
+			t.Errorf("first step = %+v, want a reallocation to a larger capacity", steps[0])
+		}
+		for _, step := range steps[1:] {
+			if step.Reallocated != (step.Data != steps[0].Data) {
+				t.Errorf("step %+v Reallocated does not match its address change", step)
+			}
+		}
+	})
+
+	t.Run("does not modify the caller's slice", func(t *testing.T) {
+		s := make([]int, 1, 10)
+		TraceAppend(s, 7, 8)
+		if len(s) != 1 {
+			t.Errorf("len(s) = %d after TraceAppend, want 1", len(s))
+		}
+	})
+
+	t.Run("format draws used and spare capacity", func(t *testing.T) {
+		out := FormatAppendTrace([]AppendStep{
+			{Len: 1, Cap: 2, Data: 0x1000, Reallocated: true},
+			{Len: 2, Cap: 2, Data: 0x1000},
+		})
+		want := "len=1   cap=2   0x1000 [#.]  <- reallocated\nlen=2   cap=2   0x1000 [##]\n"
+		if out != want {
+			t.Errorf("FormatAppendTrace() = %q, want %q", out, want)
+		}
+	})
+
+	t.Run("slices command", func(t *testing.T) {
+		var stdout strings.Builder
+		if err := runSlices([]string{"--appends", "5"}, &stdout); err != nil {
+			t.Fatalf("runSlices() error = %v", err)
+		}
+		if lines := strings.Count(stdout.String(), "\n"); lines != 5 {
+			t.Errorf("runSlices() printed %d lines, want 5", lines)
+		}
+	})
+}
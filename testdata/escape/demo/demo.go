@@ -0,0 +1,46 @@
+// Package demo is compiled by the escape tests to capture real
+// -gcflags=-m=2 diagnostics. Keep it small: every line ends up in a fixture.
+package demo
+
+func CreateOnStack() int {
+	x := 42
+	return x
+}
+
+func CreateOnHeap() *int {
+	x := 42
+	return &x
+}
+
+func MakeCounter(start int) func() int {
+	count := start
+	return func() int {
+		count++
+		return count
+	}
+}
+
+func Identity(p *int) *int {
+	return p
+}
+
+func Sum(nums []int) int {
+	total := 0
+	for _, n := range nums {
+		total += n
+	}
+	return total
+}
+
+func Grow(nums []int) []int {
+	result := make([]int, 0, len(nums)*2)
+	result = append(result, nums...)
+	result = append(result, nums...)
+	return result
+}
+
+func Use() int {
+	a := CreateOnStack()
+	b := CreateOnHeap()
+	return a + *b + Sum([]int{1, 2, 3})
+}
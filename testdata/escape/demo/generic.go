@@ -0,0 +1,9 @@
+package demo
+
+import "sync/atomic"
+
+// Latest instantiates a generic standard library type, so the compiler also
+// reports diagnostics for GOROOT's sync/atomic/type.go
+func Latest(p *atomic.Pointer[int]) *int {
+	return p.Load()
+}
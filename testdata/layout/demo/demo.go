@@ -0,0 +1,36 @@
+// Package demo holds structs with known layouts for the layout tests
+package demo
+
+import "time"
+
+// Padded wastes 8 bytes on 64-bit platforms
+type Padded struct {
+	A bool
+	B int64
+	C bool
+	D int32
+}
+
+// Tight is Padded in the suggested order
+type Tight struct {
+	B int64
+	D int32
+	A bool
+	C bool
+}
+
+// TrailingEmpty pays for a zero-sized last field
+type TrailingEmpty struct {
+	N int32
+	E struct{}
+}
+
+// Event mixes in a type from another package
+type Event struct {
+	Urgent bool
+	At     time.Time
+	ID     int32
+}
+
+// NotAStruct is skipped
+type NotAStruct []int
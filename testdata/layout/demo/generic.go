@@ -0,0 +1,8 @@
+package demo
+
+// Box is generic, so it has no layout until T is known
+type Box[T any] struct {
+	Ok    bool
+	Value T
+	Tag   int8
+}